@@ -0,0 +1,260 @@
+package klog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// OverflowPolicy controls what [*AsyncHandler] does when its ring buffer
+	// is full
+	OverflowPolicy int
+)
+
+// Overflow policies
+const (
+	// DropNewest discards the incoming record when the buffer is full
+	DropNewest OverflowPolicy = iota
+	// DropOldest discards the oldest buffered record to make room
+	DropOldest
+	// Block waits until there is room in the buffer
+	Block
+	// BlockWithTimeout waits up to the timeout set by [OptAsyncBlockTimeout]
+	// (one second by default) for room in the buffer before falling back to
+	// [DropNewest]
+	BlockWithTimeout
+)
+
+type (
+	asyncItem struct {
+		handler Handler
+		rec     Record
+	}
+
+	// asyncCore is the shared ring buffer and background goroutine behind a
+	// tree of [*AsyncHandler] produced by [AsyncHandler.Subhandler]
+	asyncCore struct {
+		policy        OverflowPolicy
+		blockTimeout  time.Duration
+		selfLogPeriod time.Duration
+		clock         Clock
+		selfLogNext   Handler
+
+		mu       sync.Mutex
+		notFull  *sync.Cond
+		notEmpty *sync.Cond
+		buf      []asyncItem
+		head     int
+		size     int
+
+		dropped atomic.Uint64
+		queued  atomic.Uint64
+		flushed atomic.Uint64
+
+		closeOnce sync.Once
+		closed    chan struct{}
+		done      chan struct{}
+	}
+
+	// AsyncHandler wraps a [Handler] and flushes buffered [Record]s to it from
+	// a background goroutine
+	AsyncHandler struct {
+		core *asyncCore
+		next Handler
+	}
+
+	// AsyncOpt is an options function for [NewAsyncHandler]
+	AsyncOpt = func(c *asyncCore)
+)
+
+// NewAsyncHandler creates a new [*AsyncHandler] buffering up to size records
+func NewAsyncHandler(next Handler, size int, policy OverflowPolicy, opts ...AsyncOpt) *AsyncHandler {
+	if size < 1 {
+		size = 1
+	}
+	c := &asyncCore{
+		policy:        policy,
+		blockTimeout:  time.Second,
+		selfLogPeriod: 0,
+		clock:         RealTime{},
+		selfLogNext:   next,
+		buf:           make([]asyncItem, size),
+		closed:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	c.notFull = sync.NewCond(&c.mu)
+	c.notEmpty = sync.NewCond(&c.mu)
+	for _, i := range opts {
+		i(c)
+	}
+	go c.run()
+	return &AsyncHandler{core: c, next: next}
+}
+
+// OptAsyncHandler returns a [LoggerOpt] that wraps the current handler in a
+// [*AsyncHandler]
+func OptAsyncHandler(size int, policy OverflowPolicy, opts ...AsyncOpt) LoggerOpt {
+	return func(l *KLogger) {
+		l.handler = NewAsyncHandler(l.handler, size, policy, opts...)
+	}
+}
+
+// OptAsyncBlockTimeout returns an [AsyncOpt] that sets the timeout used by
+// [BlockWithTimeout]
+func OptAsyncBlockTimeout(d time.Duration) AsyncOpt {
+	return func(c *asyncCore) {
+		c.blockTimeout = d
+	}
+}
+
+// OptAsyncSelfLog returns an [AsyncOpt] that periodically logs queue counters
+// to the wrapped handler as a self-log
+func OptAsyncSelfLog(period time.Duration) AsyncOpt {
+	return func(c *asyncCore) {
+		c.selfLogPeriod = period
+	}
+}
+
+// OptAsyncClock returns an [AsyncOpt] that sets the clock used for the
+// self-log
+func OptAsyncClock(clock Clock) AsyncOpt {
+	return func(c *asyncCore) {
+		c.clock = clock
+	}
+}
+
+// Enabled implements [Handler]
+func (h *AsyncHandler) Enabled(ctx context.Context, level Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements [Handler] and enqueues rec for the background goroutine
+// to flush to h's handler. rec is cloned before enqueuing since slog.Record
+// carries attrs in an inline array that the caller may reuse.
+func (h *AsyncHandler) Handle(ctx context.Context, rec Record) error {
+	return h.core.enqueue(asyncItem{handler: h.next, rec: rec.Clone()})
+}
+
+func (c *asyncCore) enqueue(item asyncItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size == len(c.buf) {
+		switch c.policy {
+		case DropOldest:
+			c.head = (c.head + 1) % len(c.buf)
+			c.size--
+			c.dropped.Add(1)
+		case Block:
+			for c.size == len(c.buf) {
+				c.notFull.Wait()
+			}
+		case BlockWithTimeout:
+			if !c.waitNotFullWithTimeout() {
+				c.dropped.Add(1)
+				return nil
+			}
+		default: // DropNewest
+			c.dropped.Add(1)
+			return nil
+		}
+	}
+
+	c.buf[(c.head+c.size)%len(c.buf)] = item
+	c.size++
+	c.queued.Add(1)
+	c.notEmpty.Signal()
+	return nil
+}
+
+// waitNotFullWithTimeout waits for buffer space up to c.blockTimeout while
+// holding c.mu, reporting whether space became available
+func (c *asyncCore) waitNotFullWithTimeout() bool {
+	deadline := c.clock.Time().Add(c.blockTimeout)
+	for c.size == len(c.buf) {
+		timer := time.AfterFunc(time.Until(deadline), c.notFull.Broadcast)
+		c.notFull.Wait()
+		timer.Stop()
+		if c.clock.Time().After(deadline) {
+			return c.size < len(c.buf)
+		}
+	}
+	return true
+}
+
+// run flushes buffered records until Close drains the buffer
+func (c *asyncCore) run() {
+	defer close(c.done)
+
+	var selfLogAt time.Time
+	for {
+		c.mu.Lock()
+		for c.size == 0 {
+			select {
+			case <-c.closed:
+				c.mu.Unlock()
+				return
+			default:
+			}
+			c.notEmpty.Wait()
+		}
+		item := c.buf[c.head]
+		c.buf[c.head] = asyncItem{}
+		c.head = (c.head + 1) % len(c.buf)
+		c.size--
+		c.notFull.Signal()
+		c.mu.Unlock()
+
+		// ignore errors for failing to handle logs
+		_ = item.handler.Handle(context.Background(), item.rec)
+		c.flushed.Add(1)
+
+		if c.selfLogPeriod > 0 {
+			if now := c.clock.Time(); now.Sub(selfLogAt) >= c.selfLogPeriod {
+				selfLogAt = now
+				c.logSelf(now)
+			}
+		}
+	}
+}
+
+// logSelf emits a self-log record carrying the current queue counters
+func (c *asyncCore) logSelf(now time.Time) {
+	rec := NewRecord(now, LevelInfo, "klog async handler stats", 0)
+	rec.AddAttrs(
+		AUint64("async.dropped", c.dropped.Load()),
+		AUint64("async.queued", c.queued.Load()),
+		AUint64("async.flushed", c.flushed.Load()),
+	)
+	_ = c.selfLogNext.Handle(context.Background(), rec)
+}
+
+// Close drains buffered records and stops the background goroutine, returning
+// early if ctx is canceled before the drain completes. Close stops the shared
+// background goroutine for every [*AsyncHandler] produced by [Subhandler] and
+// should therefore only be called on the top level handler.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	h.core.closeOnce.Do(func() {
+		close(h.core.closed)
+		h.core.mu.Lock()
+		h.core.notEmpty.Broadcast()
+		h.core.mu.Unlock()
+	})
+	select {
+	case <-h.core.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subhandler implements [Handler] and shares the background goroutine and
+// ring buffer with h
+func (h *AsyncHandler) Subhandler(modSegment string, attrs []Attr) Handler {
+	return &AsyncHandler{
+		core: h.core,
+		next: h.next.Subhandler(modSegment, attrs),
+	}
+}
@@ -0,0 +1,110 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flushes queued records", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		var b bytes.Buffer
+		h := NewAsyncHandler(NewJSONSlogHandler(NewSyncWriter(&b)), 16, Block)
+		l := New(OptHandler(h), OptMinLevel(LevelDebug))
+
+		for i := 0; i < 4; i++ {
+			l.Log(context.Background(), LevelInfo, 0, "queued message")
+		}
+
+		assert.NoError(h.Close(context.Background()))
+
+		d := json.NewDecoder(&b)
+		var seen int
+		for d.More() {
+			var j map[string]any
+			assert.NoError(d.Decode(&j))
+			assert.Equal("queued message", j["msg"])
+			seen++
+		}
+		assert.Equal(4, seen)
+	})
+
+	t.Run("captures error stack trace at the log site, not the flush goroutine", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		var b bytes.Buffer
+		h := NewAsyncHandler(NewJSONSlogHandler(NewSyncWriter(&b)), 16, Block)
+		l := NewLevelLogger(New(OptHandler(h), OptMinLevel(LevelDebug)))
+
+		l.Err(context.Background(), errors.New("boom"))
+
+		assert.NoError(h.Close(context.Background()))
+
+		var j map[string]any
+		assert.NoError(json.Unmarshal(b.Bytes(), &j))
+		logerr, ok := j["err"].(map[string]any)
+		assert.True(ok)
+		stack, ok := logerr["stack"].([]any)
+		assert.True(ok)
+		assert.NotEmpty(stack)
+		frame, ok := stack[0].(map[string]any)
+		assert.True(ok)
+		// the stack must reflect this test's call to Err, captured before the
+		// record was handed off to the background flush goroutine, not
+		// asyncCore.run or the deferred [ErrorMarshaler]
+		assert.Contains(frame["fn"], "xorkevin.dev/klog.TestAsyncHandler")
+		assert.Contains(frame["file"], "xorkevin.dev/klog/async_handler_test.go")
+	})
+
+	t.Run("drops newest when full", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		block := make(chan struct{})
+		h := NewAsyncHandler(blockingHandler{ch: block}, 1, DropNewest)
+		l := New(OptHandler(h), OptMinLevel(LevelDebug))
+
+		l.Log(context.Background(), LevelInfo, 0, "first")
+		// give the background goroutine a chance to dequeue the first record
+		// and block on it before enqueuing more
+		time.Sleep(10 * time.Millisecond)
+		l.Log(context.Background(), LevelInfo, 0, "second")
+		l.Log(context.Background(), LevelInfo, 0, "third")
+
+		// "first" is in flight and "second" fills the one slot, so "third" is
+		// the one dropped
+		assert.Equal(uint64(1), h.core.dropped.Load())
+
+		close(block)
+		assert.NoError(h.Close(context.Background()))
+	})
+}
+
+type (
+	blockingHandler struct {
+		ch chan struct{}
+	}
+)
+
+func (h blockingHandler) Enabled(ctx context.Context, level Level) bool { return true }
+
+func (h blockingHandler) Handle(ctx context.Context, rec Record) error {
+	<-h.ch
+	return nil
+}
+
+func (h blockingHandler) Subhandler(modSegment string, attrs []Attr) Handler { return h }
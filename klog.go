@@ -10,18 +10,10 @@ import (
 )
 
 type (
-	Level  = slog.Level
 	Attr   = slog.Attr
 	Record = slog.Record
 )
 
-const (
-	LevelDebug = slog.LevelDebug
-	LevelInfo  = slog.LevelInfo
-	LevelWarn  = slog.LevelWarn
-	LevelError = slog.LevelError
-)
-
 func AString(key string, value string) Attr {
 	return slog.String(key, value)
 }
@@ -63,7 +55,7 @@ func AAny(key string, value any) Attr {
 }
 
 func NewRecord(t time.Time, level Level, msg string, pc uintptr) Record {
-	return slog.NewRecord(t, level, msg, pc)
+	return slog.NewRecord(t, ToSlogLevel(level), msg, pc)
 }
 
 type (
@@ -84,9 +76,10 @@ type (
 
 	// KLogger is a context logger that writes logs to a [Handler]
 	KLogger struct {
-		handler  Handler
-		minLevel Level
-		clock    Clock
+		handler     Handler
+		minLevel    Level
+		minLevelVar *LevelVar
+		clock       Clock
 	}
 
 	// Clock returns the current and monotonic time
@@ -146,6 +139,15 @@ func OptMinLevelStr(s string) LoggerOpt {
 	return OptMinLevel(level)
 }
 
+// OptMinLevelVar returns a [LoggerOpt] that sets [KLogger] minLevel from a
+// [*LevelVar], allowing the minimum level to be changed at runtime without
+// rebuilding the logger tree
+func OptMinLevelVar(v *LevelVar) LoggerOpt {
+	return func(l *KLogger) {
+		l.minLevelVar = v
+	}
+}
+
 // OptClock returns a [LoggerOpt] that sets [KLogger] clock
 func OptClock(c Clock) LoggerOpt {
 	return func(l *KLogger) {
@@ -155,7 +157,16 @@ func OptClock(c Clock) LoggerOpt {
 
 // Enabled implements [Logger] and returns if the logger is enabled for a level
 func (l *KLogger) Enabled(ctx context.Context, level Level) bool {
-	return level >= l.minLevel && l.handler.Enabled(ctx, level)
+	return level >= l.minLevelOrDefault() && l.handler.Enabled(ctx, level)
+}
+
+// minLevelOrDefault returns the current minimum level, preferring
+// minLevelVar when set by [OptMinLevelVar]
+func (l *KLogger) minLevelOrDefault() Level {
+	if l.minLevelVar != nil {
+		return l.minLevelVar.Level()
+	}
+	return l.minLevel
 }
 
 // Log implements [Logger] and logs an event to its handler
@@ -167,7 +178,7 @@ func (l *KLogger) Log(ctx context.Context, level Level, skip int, msg string, at
 	t := l.clock.Time() // monotonic time
 	pc := linepc(1 + skip)
 
-	rec := slog.NewRecord(t, level, msg, pc)
+	rec := slog.NewRecord(t, ToSlogLevel(level), msg, pc)
 	rec.AddAttrs(attrs...)
 
 	// ignore errors for failing to handle logs
@@ -190,9 +201,10 @@ func (l *KLogger) Handler() Handler {
 // Sublogger implements [SubLogger] and creates a new sublogger
 func (l *KLogger) Sublogger(modSegment string, attrs ...Attr) Logger {
 	return &KLogger{
-		handler:  l.handler.Subhandler(modSegment, attrs),
-		minLevel: l.minLevel,
-		clock:    l.clock,
+		handler:     l.handler.Subhandler(modSegment, attrs),
+		minLevel:    l.minLevel,
+		minLevelVar: l.minLevelVar,
+		clock:       l.clock,
 	}
 }
 
@@ -110,6 +110,23 @@ func TestKLogger(t *testing.T) {
 	}
 }
 
+func TestMinLevelVar(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	v := NewLevelVar(LevelWarn)
+	l := New(OptHandler(NewJSONSlogHandler(NewSyncWriter(&b))), OptMinLevelVar(v))
+
+	l.Log(context.Background(), LevelInfo, 0, "should be dropped")
+	assert.Equal(0, b.Len())
+
+	v.Set(LevelInfo)
+	l.Log(context.Background(), LevelInfo, 0, "should pass")
+	assert.Greater(b.Len(), 0)
+}
+
 func TestDiscard(t *testing.T) {
 	t.Parallel()
 
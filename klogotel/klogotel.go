@@ -0,0 +1,53 @@
+// Package klogotel correlates [klog] log records with an active
+// OpenTelemetry span.
+package klogotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"xorkevin.dev/klog"
+)
+
+// SpanFromContext implements [klog.SlogHandler.SpanFromContext] by reading
+// the span context from an active OpenTelemetry span on ctx
+func SpanFromContext(ctx context.Context) klog.SpanContext {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return klog.SpanContext{}
+	}
+	return klog.SpanContext{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		TraceFlags: sc.TraceFlags().String(),
+		IsValid:    true,
+	}
+}
+
+// OptSlogHandlerOtel configures h to inject trace_id/span_id/trace_flags
+// attrs from the active OpenTelemetry span on the log context
+func OptSlogHandlerOtel(h *klog.SlogHandler) {
+	h.SpanFromContext = SpanFromContext
+}
+
+// OptSlogHandlerSpanEvents configures h to also record every log at
+// [klog.LevelError] or above as an event on the active OpenTelemetry span
+func OptSlogHandlerSpanEvents(h *klog.SlogHandler) {
+	h.SpanEventBridge = SpanEventBridge
+}
+
+// SpanEventBridge implements [klog.SlogHandler.SpanEventBridge] by adding an
+// event to the active OpenTelemetry span on ctx for every log record
+func SpanEventBridge(ctx context.Context, r klog.Record) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a klog.Attr) bool {
+		attrs = append(attrs, attribute.String(a.Key, a.Value.String()))
+		return true
+	})
+	span.AddEvent(r.Message, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,46 @@
+package klogotel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"xorkevin.dev/klog"
+)
+
+func TestSpanFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("klogotel_test")
+
+	ctx, span := tracer.Start(context.Background(), "op")
+
+	var b bytes.Buffer
+	h := klog.NewJSONSlogHandler(klog.NewSyncWriter(&b))
+	OptSlogHandlerOtel(h)
+	OptSlogHandlerSpanEvents(h)
+
+	l := klog.New(klog.OptHandler(h), klog.OptMinLevel(klog.LevelDebug))
+	l.Log(ctx, klog.LevelError, 0, "span correlated error")
+	span.End()
+
+	var j map[string]any
+	assert.NoError(json.Unmarshal(b.Bytes(), &j))
+	sc := span.SpanContext()
+	assert.Equal(sc.TraceID().String(), j["trace_id"])
+	assert.Equal(sc.SpanID().String(), j["span_id"])
+
+	spans := recorder.Ended()
+	assert.Len(spans, 1)
+	events := spans[0].Events()
+	assert.Len(events, 1)
+	assert.Equal("span correlated error", events[0].Name)
+}
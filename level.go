@@ -1,5 +1,11 @@
 package klog
 
+import (
+	"sync/atomic"
+
+	"golang.org/x/exp/slog"
+)
+
 type (
 	// Level is a log level
 	Level int
@@ -7,16 +13,20 @@ type (
 
 // Log levels
 const (
-	LevelDebug Level = iota
+	LevelTrace Level = iota
+	LevelDebug
 	LevelInfo
 	LevelWarn
 	LevelError
+	LevelFatal
 	LevelNone
 )
 
 // String implements [fmt.Stringer]
 func (l Level) String() string {
 	switch l {
+	case LevelTrace:
+		return "TRACE"
 	case LevelDebug:
 		return "DEBUG"
 	case LevelInfo:
@@ -25,6 +35,8 @@ func (l Level) String() string {
 		return "WARN"
 	case LevelError:
 		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
 	case LevelNone:
 		return "NONE"
 	default:
@@ -37,9 +49,13 @@ func (l Level) MarshalText() ([]byte, error) {
 	return []byte(l.String()), nil
 }
 
-// UnmarshalText implements [encoding.TextUnmarshaler]
+// UnmarshalText implements [encoding.TextUnmarshaler]. Unknown level names
+// default to [LevelInfo] to remain backwards compatible with callers that
+// predate [LevelTrace] and [LevelFatal].
 func (l *Level) UnmarshalText(data []byte) error {
 	switch string(data) {
+	case "TRACE":
+		*l = LevelTrace
 	case "DEBUG":
 		*l = LevelDebug
 	case "INFO":
@@ -48,6 +64,8 @@ func (l *Level) UnmarshalText(data []byte) error {
 		*l = LevelWarn
 	case "ERROR":
 		*l = LevelError
+	case "FATAL":
+		*l = LevelFatal
 	case "NONE":
 		*l = LevelNone
 	default:
@@ -55,3 +73,69 @@ func (l *Level) UnmarshalText(data []byte) error {
 	}
 	return nil
 }
+
+type (
+	// LevelVar is an atomically updatable [Level], allowing the minimum level
+	// of a running [KLogger] to be changed without rebuilding the logger tree
+	LevelVar struct {
+		v atomic.Int64
+	}
+)
+
+// NewLevelVar creates a new [*LevelVar] set to l
+func NewLevelVar(l Level) *LevelVar {
+	v := &LevelVar{}
+	v.Set(l)
+	return v
+}
+
+// Level returns the current [Level]
+func (v *LevelVar) Level() Level {
+	return Level(v.v.Load())
+}
+
+// Set atomically updates the current [Level]
+func (v *LevelVar) Set(l Level) {
+	v.v.Store(int64(l))
+}
+
+// ToSlogLevel maps a [Level] to the closest [slog.Level], since [Record] is
+// backed by [slog.Record] and has no native representation for [LevelTrace],
+// [LevelFatal], or [LevelNone]
+func ToSlogLevel(l Level) slog.Level {
+	switch l {
+	case LevelTrace:
+		return slog.LevelDebug - 4
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelFatal:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError + 8
+	}
+}
+
+// FromSlogLevel maps an [slog.Level] on a [Record] back to the closest
+// [Level]
+func FromSlogLevel(l slog.Level) Level {
+	switch {
+	case l < slog.LevelDebug:
+		return LevelTrace
+	case l < slog.LevelInfo:
+		return LevelDebug
+	case l < slog.LevelWarn:
+		return LevelInfo
+	case l < slog.LevelError:
+		return LevelWarn
+	case l < slog.LevelError+4:
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
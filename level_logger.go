@@ -2,6 +2,9 @@ package klog
 
 import (
 	"context"
+	"os"
+
+	"xorkevin.dev/kerrors"
 )
 
 type (
@@ -20,6 +23,11 @@ func NewLevelLogger(l Logger) *LevelLogger {
 	}
 }
 
+// Trace logs at [LevelTrace]
+func (l *LevelLogger) Trace(ctx context.Context, msg string, attrs ...Attr) {
+	l.Logger.Log(ctx, LevelTrace, 1+l.Skip, msg, attrs...)
+}
+
 // Debug logs at [LevelDebug]
 func (l *LevelLogger) Debug(ctx context.Context, msg string, attrs ...Attr) {
 	l.Logger.Log(ctx, LevelDebug, 1+l.Skip, msg, attrs...)
@@ -37,7 +45,11 @@ func (l *LevelLogger) Warn(ctx context.Context, msg string, attrs ...Attr) {
 
 // WarnErr logs at [LevelWarn]
 func (l *LevelLogger) WarnErr(ctx context.Context, err error, attrs ...Attr) {
-	l.Logger.Log(ctx, LevelWarn, 1+l.Skip, err.Error(), AAny("err", err), AGroup("", attrs...))
+	// stamp the stack trace here using the same skip depth passed to Log, so
+	// it reflects this call site rather than wherever [AErr] happens to add
+	// one
+	err = kerrors.AddStackTrace(err, 1+l.Skip)
+	l.Logger.Log(ctx, LevelWarn, 1+l.Skip, err.Error(), AErr("err", err), AGroup("", attrs...))
 }
 
 // Error logs at [LevelError]
@@ -47,5 +59,21 @@ func (l *LevelLogger) Error(ctx context.Context, msg string, attrs ...Attr) {
 
 // Err logs an error [LevelError]
 func (l *LevelLogger) Err(ctx context.Context, err error, attrs ...Attr) {
-	l.Logger.Log(ctx, LevelError, 1+l.Skip, err.Error(), AAny("err", err), AGroup("", attrs...))
+	// stamp the stack trace here using the same skip depth passed to Log, so
+	// it reflects this call site rather than wherever [AErr] happens to add
+	// one
+	err = kerrors.AddStackTrace(err, 1+l.Skip)
+	l.Logger.Log(ctx, LevelError, 1+l.Skip, err.Error(), AErr("err", err), AGroup("", attrs...))
+}
+
+// Fatal logs at [LevelFatal], flushes the logger's handler if it supports
+// flushing, and then calls os.Exit(1)
+func (l *LevelLogger) Fatal(ctx context.Context, msg string, attrs ...Attr) {
+	l.Logger.Log(ctx, LevelFatal, 1+l.Skip, msg, attrs...)
+	if f, ok := l.Logger.Handler().(interface {
+		Close(ctx context.Context) error
+	}); ok {
+		_ = f.Close(ctx)
+	}
+	os.Exit(1)
 }
@@ -115,9 +115,20 @@ func TestLevelLogger(t *testing.T) {
 			assert.NoError(d.Decode(&j))
 			assert.Equal("ERROR", j["level"])
 			assert.Equal("plain error", j["msg"])
-			logerr, ok := j["err"].(string)
+			logerr, ok := j["err"].(map[string]any)
+			assert.True(ok)
+			// a plain error has no stack of its own, so Err attaches one
+			// captured at the log site before the [ErrorMarshaler] runs
+			stack, ok := logerr["stack"].([]any)
 			assert.True(ok)
-			assert.Equal("plain error", logerr)
+			assert.NotNil(stack)
+			assert.Contains(stack[0].(map[string]any)["file"], "xorkevin.dev/klog/level_logger_test.go")
+			assert.Contains(stack[0].(map[string]any)["fn"], "xorkevin.dev/klog.TestLevelLogger")
+			delete(logerr, "stack")
+			assert.Equal(map[string]any{
+				"msg":   "Stack trace",
+				"cause": "plain error",
+			}, logerr)
 		}
 		{
 			var j map[string]any
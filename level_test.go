@@ -13,6 +13,10 @@ func TestLevel(t *testing.T) {
 		Test  string
 		Level Level
 	}{
+		{
+			Test:  "TRACE",
+			Level: LevelTrace,
+		},
 		{
 			Test:  "DEBUG",
 			Level: LevelDebug,
@@ -29,6 +33,10 @@ func TestLevel(t *testing.T) {
 			Test:  "ERROR",
 			Level: LevelError,
 		},
+		{
+			Test:  "FATAL",
+			Level: LevelFatal,
+		},
 		{
 			Test:  "NONE",
 			Level: LevelNone,
@@ -64,3 +72,14 @@ func TestLevel(t *testing.T) {
 		assert.Equal("UNSET", Level(-1).String())
 	})
 }
+
+func TestLevelVar(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := NewLevelVar(LevelInfo)
+	assert.Equal(LevelInfo, v.Level())
+	v.Set(LevelWarn)
+	assert.Equal(LevelWarn, v.Level())
+}
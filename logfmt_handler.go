@@ -0,0 +1,182 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+type (
+	// LogfmtHandler writes logs as logfmt key=value lines
+	LogfmtHandler struct {
+		FieldTime    string
+		FieldTimeLoc *time.Location
+		FieldSrc     string
+		FieldMod     string
+		ModSeparator string
+		Mod          string
+		MinLevel     Level
+		W            io.Writer
+		attrKeySet   map[string]struct{}
+		presetAttrs  []Attr
+	}
+)
+
+// NewLogfmtHandler creates a new [*LogfmtHandler]
+func NewLogfmtHandler(w io.Writer) *LogfmtHandler {
+	return &LogfmtHandler{
+		FieldTime:    "t",
+		FieldTimeLoc: time.UTC,
+		FieldSrc:     "src",
+		FieldMod:     "mod",
+		ModSeparator: ".",
+		Mod:          "",
+		MinLevel:     LevelDebug,
+		W:            w,
+		attrKeySet:   map[string]struct{}{},
+	}
+}
+
+func (h *LogfmtHandler) clone() *LogfmtHandler {
+	return &LogfmtHandler{
+		FieldTime:    h.FieldTime,
+		FieldTimeLoc: h.FieldTimeLoc,
+		FieldSrc:     h.FieldSrc,
+		FieldMod:     h.FieldMod,
+		ModSeparator: h.ModSeparator,
+		Mod:          h.Mod,
+		MinLevel:     h.MinLevel,
+		W:            h.W,
+		attrKeySet:   cloneAttrKeySet(h.attrKeySet),
+		presetAttrs:  append([]Attr{}, h.presetAttrs...),
+	}
+}
+
+func cloneAttrKeySet(s map[string]struct{}) map[string]struct{} {
+	s2 := make(map[string]struct{}, len(s))
+	for k := range s {
+		s2[k] = struct{}{}
+	}
+	return s2
+}
+
+func (h *LogfmtHandler) checkAttrKey(k string) bool {
+	if k == "" {
+		return true
+	}
+	if k == h.FieldTime || k == h.FieldSrc || k == h.FieldMod {
+		return true
+	}
+	if _, ok := h.attrKeySet[k]; ok {
+		return true
+	}
+	return false
+}
+
+// Enabled implements [Handler]
+func (h *LogfmtHandler) Enabled(ctx context.Context, level Level) bool {
+	return level >= h.MinLevel
+}
+
+// Handle implements [Handler]
+func (h *LogfmtHandler) Handle(ctx context.Context, r Record) error {
+	var buf bytes.Buffer
+	buf.WriteString("level=")
+	buf.WriteString(FromSlogLevel(r.Level).String())
+	if h.FieldTime != "" && !r.Time.IsZero() {
+		writeLogfmtPair(&buf, h.FieldTime, r.Time.In(h.FieldTimeLoc).Format(time.RFC3339Nano))
+	}
+	if h.FieldMod != "" && h.Mod != "" {
+		writeLogfmtPair(&buf, h.FieldMod, h.Mod)
+	}
+	writeLogfmtPair(&buf, "msg", r.Message)
+	if h.FieldSrc != "" && r.PC != 0 {
+		frame := linecaller(r.PC)
+		writeLogfmtPair(&buf, h.FieldSrc+".fn", frame.Function)
+		writeLogfmtPair(&buf, h.FieldSrc+".file", frame.File+":"+strconv.Itoa(frame.Line))
+	}
+	for _, attr := range h.presetAttrs {
+		writeLogfmtAttr(&buf, attr.Key, attr.Value)
+	}
+
+	attrKeys := map[string]struct{}{}
+	addFilteredAttrs := func(attr Attr) bool {
+		if h.checkAttrKey(attr.Key) {
+			return true
+		}
+		if _, ok := attrKeys[attr.Key]; ok {
+			return true
+		}
+		attrKeys[attr.Key] = struct{}{}
+		writeLogfmtAttr(&buf, attr.Key, attr.Value)
+		return true
+	}
+	// ctx attrs have precedence of child before parent adhering to
+	// [context.Context] Value semantics
+	for ctxAttrs := getCtxAttrs(ctx); ctxAttrs != nil; ctxAttrs = ctxAttrs.parent {
+		ctxAttrs.attrs.readAttrs(addFilteredAttrs)
+	}
+	// attrs on the record have lowest precedence as to avoid overriding attrs
+	// on the context and handler
+	r.Attrs(addFilteredAttrs)
+	buf.WriteByte('\n')
+	_, err := h.W.Write(buf.Bytes())
+	return err
+}
+
+// writeLogfmtAttr writes attr to buf, flattening groups as dotted keys
+func writeLogfmtAttr(buf *bytes.Buffer, key string, v slog.Value) {
+	v = v.Resolve()
+	if v.Kind() == slog.KindGroup {
+		for _, a := range v.Group() {
+			childKey := a.Key
+			if key != "" && childKey != "" {
+				childKey = key + "." + childKey
+			} else if childKey == "" {
+				childKey = key
+			}
+			writeLogfmtAttr(buf, childKey, a.Value)
+		}
+		return
+	}
+	writeLogfmtPair(buf, key, v.String())
+}
+
+func writeLogfmtPair(buf *bytes.Buffer, key, value string) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	buf.WriteString(logfmtQuote(value))
+}
+
+// logfmtQuote quotes v per the logfmt spec if it contains a space, `=`, `"`,
+// a newline, or is empty
+func logfmtQuote(v string) string {
+	if v != "" && !strings.ContainsAny(v, " =\"\n") {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+// Subhandler implements [Handler]
+func (h *LogfmtHandler) Subhandler(modSegment string, attrs []Attr) Handler {
+	h2 := h.clone()
+	if modSegment != "" {
+		h2.Mod += h2.ModSeparator + modSegment
+	}
+	for _, i := range attrs {
+		if h2.checkAttrKey(i.Key) {
+			continue
+		}
+		h2.attrKeySet[i.Key] = struct{}{}
+		h2.presetAttrs = append(h2.presetAttrs, i)
+	}
+	return h2
+}
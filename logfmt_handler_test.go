@@ -0,0 +1,27 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogfmtHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	h := NewLogfmtHandler(NewSyncWriter(&b))
+	h.FieldTime = ""
+	h.FieldSrc = ""
+	var l Logger = New(OptHandler(h), OptMinLevel(LevelDebug), OptClock(testClock{t: time.Now()}))
+	l = l.Sublogger("mod", AString("f1", "has space"))
+
+	l.Log(context.Background(), LevelInfo, 0, "hello world", AString("f2", "v2"))
+
+	assert.Equal("level=INFO mod=.mod msg=\"hello world\" f1=\"has space\" f2=v2\n", b.String())
+}
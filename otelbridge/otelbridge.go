@@ -0,0 +1,271 @@
+// Package otelbridge converts klog attributes to and from the OpenTelemetry
+// [Logs Bridge API] and provides a [klog.Handler] that emits records through
+// an [otellog.LoggerProvider].
+//
+// [Logs Bridge API]: https://pkg.go.dev/go.opentelemetry.io/otel/log
+package otelbridge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	expslog "golang.org/x/exp/slog"
+	"xorkevin.dev/klog"
+)
+
+// ToOtelValue converts a [klog.Value] to an [otellog.Value], resolving
+// [klog.LogValuer] chains first. KindTime and KindDuration values are
+// formatted as ISO 8601 strings, matching OpenTelemetry semantic convention
+// recommendations for attributes without a native time representation.
+func ToOtelValue(v klog.Value) otellog.Value {
+	v = v.Resolve()
+	switch v.Kind() {
+	case klog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case klog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case klog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case klog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case klog.KindString:
+		return otellog.StringValue(v.StringValue())
+	case klog.KindBytes:
+		return otellog.BytesValue(v.Bytes())
+	case klog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	case klog.KindDuration:
+		return otellog.StringValue(formatISODuration(v.Duration()))
+	case klog.KindGroup:
+		g := v.Group()
+		out := make([]otellog.KeyValue, len(g))
+		for i, a := range g {
+			out[i] = ToKeyValue(a)
+		}
+		return otellog.MapValue(out...)
+	default:
+		return otellog.StringValue(fmt.Sprint(v.Any()))
+	}
+}
+
+// FromOtelValue converts an [otellog.Value] to a [klog.Value]
+func FromOtelValue(v otellog.Value) klog.Value {
+	switch v.Kind() {
+	case otellog.KindBool:
+		return klog.ValueBool(v.AsBool())
+	case otellog.KindInt64:
+		return klog.ValueInt64(v.AsInt64())
+	case otellog.KindFloat64:
+		return klog.ValueFloat64(v.AsFloat64())
+	case otellog.KindString:
+		return klog.ValueString(v.AsString())
+	case otellog.KindBytes:
+		return klog.ValueBytes(v.AsBytes())
+	case otellog.KindSlice:
+		s := v.AsSlice()
+		attrs := make([]klog.Attr, len(s))
+		for i, e := range s {
+			attrs[i] = klog.AAny(fmt.Sprint(i), FromOtelValue(e).Any())
+		}
+		return klog.ValueGroup(attrs...)
+	case otellog.KindMap:
+		m := v.AsMap()
+		attrs := make([]klog.Attr, len(m))
+		for i, kv := range m {
+			attrs[i] = klog.AAny(kv.Key, FromOtelValue(kv.Value).Any())
+		}
+		return klog.ValueGroup(attrs...)
+	default:
+		return klog.Value{}
+	}
+}
+
+// ToKeyValue converts a [klog.Attr] (an [expslog.Attr]) to an
+// [otellog.KeyValue]
+func ToKeyValue(a klog.Attr) otellog.KeyValue {
+	return otellog.KeyValue{Key: a.Key, Value: toOtelValueFromExp(a.Value)}
+}
+
+// FromKeyValue converts an [otellog.KeyValue] to a [klog.Attr] (an
+// [expslog.Attr])
+func FromKeyValue(kv otellog.KeyValue) klog.Attr {
+	return expslog.Attr{Key: kv.Key, Value: fromOtelValueToExp(kv.Value)}
+}
+
+func toOtelValueFromExp(v expslog.Value) otellog.Value {
+	v = v.Resolve()
+	switch v.Kind() {
+	case expslog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case expslog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case expslog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case expslog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case expslog.KindString:
+		return otellog.StringValue(v.String())
+	case expslog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	case expslog.KindDuration:
+		return otellog.StringValue(formatISODuration(v.Duration()))
+	case expslog.KindGroup:
+		g := v.Group()
+		out := make([]otellog.KeyValue, len(g))
+		for i, a := range g {
+			out[i] = ToKeyValue(a)
+		}
+		return otellog.MapValue(out...)
+	default:
+		return otellog.StringValue(v.String())
+	}
+}
+
+func fromOtelValueToExp(v otellog.Value) expslog.Value {
+	switch v.Kind() {
+	case otellog.KindBool:
+		return expslog.BoolValue(v.AsBool())
+	case otellog.KindInt64:
+		return expslog.Int64Value(v.AsInt64())
+	case otellog.KindFloat64:
+		return expslog.Float64Value(v.AsFloat64())
+	case otellog.KindString:
+		return expslog.StringValue(v.AsString())
+	case otellog.KindBytes:
+		return expslog.AnyValue(v.AsBytes())
+	case otellog.KindSlice:
+		s := v.AsSlice()
+		attrs := make([]expslog.Attr, len(s))
+		for i, e := range s {
+			attrs[i] = expslog.Any(fmt.Sprint(i), fromOtelValueToExp(e).Any())
+		}
+		return expslog.GroupValue(attrs...)
+	case otellog.KindMap:
+		m := v.AsMap()
+		attrs := make([]expslog.Attr, len(m))
+		for i, kv := range m {
+			attrs[i] = FromKeyValue(kv)
+		}
+		return expslog.GroupValue(attrs...)
+	default:
+		return expslog.Value{}
+	}
+}
+
+// formatISODuration formats d as an ISO 8601 duration such as "PT1H30M5.5S"
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	neg := d < 0
+	if neg {
+		d = -d
+	}
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d.Seconds()
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+	return fmt.Sprintf("%sPT%dH%dM%gS", sign, h, m, s)
+}
+
+// toSeverity maps a [klog.Level] to the closest [otellog.Severity]
+func toSeverity(level klog.Level) otellog.Severity {
+	switch {
+	case level < klog.LevelDebug:
+		return otellog.SeverityTrace
+	case level < klog.LevelInfo:
+		return otellog.SeverityDebug
+	case level < klog.LevelWarn:
+		return otellog.SeverityInfo
+	case level < klog.LevelError:
+		return otellog.SeverityWarn
+	case level < klog.LevelFatal:
+		return otellog.SeverityError
+	default:
+		return otellog.SeverityFatal
+	}
+}
+
+// Handler implements [klog.Handler] by emitting records through an
+// [otellog.Logger]
+type Handler struct {
+	logger     otellog.Logger
+	mod        string
+	attrs      []otellog.KeyValue
+	WithSpanID bool
+}
+
+// NewHandler creates a new [*Handler] that emits through the Logger named
+// name on provider
+func NewHandler(provider otellog.LoggerProvider, name string) *Handler {
+	return &Handler{
+		logger: provider.Logger(name),
+	}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level klog.Level) bool {
+	return true
+}
+
+func (h *Handler) Handle(ctx context.Context, r klog.Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetSeverity(toSeverity(klog.FromSlogLevel(r.Level)))
+	rec.SetSeverityText(klog.FromSlogLevel(r.Level).String())
+	rec.SetBody(otellog.StringValue(r.Message))
+	if h.mod != "" {
+		rec.AddAttributes(otellog.String("mod", h.mod))
+	}
+	rec.AddAttributes(h.attrs...)
+	if h.WithSpanID {
+		rec.AddAttributes(ContextAttrs(ctx)...)
+	}
+	r.Attrs(func(a klog.Attr) bool {
+		rec.AddAttributes(ToKeyValue(a))
+		return true
+	})
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+func (h *Handler) Subhandler(modSegment string, attrs []klog.Attr) klog.Handler {
+	h2 := &Handler{
+		logger:     h.logger,
+		mod:        h.mod,
+		WithSpanID: h.WithSpanID,
+	}
+	if modSegment != "" {
+		if h2.mod != "" {
+			h2.mod += "." + modSegment
+		} else {
+			h2.mod = modSegment
+		}
+	}
+	h2.attrs = make([]otellog.KeyValue, 0, len(h.attrs)+len(attrs))
+	h2.attrs = append(h2.attrs, h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, ToKeyValue(a))
+	}
+	return h2
+}
+
+// ContextAttrs returns trace_id/span_id attributes for the active
+// OpenTelemetry span on ctx, or nil if there is no active span
+func ContextAttrs(ctx context.Context) []otellog.KeyValue {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []otellog.KeyValue{
+		otellog.String("trace_id", sc.TraceID().String()),
+		otellog.String("span_id", sc.SpanID().String()),
+	}
+}
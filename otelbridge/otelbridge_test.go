@@ -0,0 +1,122 @@
+package otelbridge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	"xorkevin.dev/klog"
+)
+
+func TestValueRoundTrip(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	v := klog.ValueGroup(
+		klog.AString("s", "hello"),
+		klog.AInt64("i", 42),
+		klog.ABool("b", true),
+	)
+
+	ov := ToOtelValue(v)
+	assert.Equal(otellog.KindMap, ov.Kind())
+
+	back := FromOtelValue(ov)
+	assert.Equal(klog.KindGroup, back.Kind())
+	g := back.Group()
+	assert.Len(g, 3)
+	assert.Equal("hello", g[0].Value.Any())
+	assert.Equal(int64(42), g[1].Value.Any())
+	assert.Equal(true, g[2].Value.Any())
+}
+
+func TestValueBytesRoundTrip(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	ov := ToOtelValue(klog.ValueBytes([]byte("payload")))
+	assert.Equal(otellog.KindBytes, ov.Kind())
+
+	back := FromOtelValue(ov)
+	assert.Equal(klog.KindBytes, back.Kind())
+	assert.Equal([]byte("payload"), back.Bytes())
+}
+
+func TestToOtelValueTimeDuration(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	now := time.Date(2024, 2, 23, 12, 0, 0, 0, time.UTC)
+	tv := ToOtelValue(klog.ValueTime(now))
+	assert.Equal(otellog.KindString, tv.Kind())
+	assert.Equal(now.Format(time.RFC3339Nano), tv.AsString())
+
+	dv := ToOtelValue(klog.ValueDuration(90 * time.Minute))
+	assert.Equal("PT1H30M0S", dv.AsString())
+
+	zv := ToOtelValue(klog.ValueDuration(0))
+	assert.Equal("PT0S", zv.AsString())
+}
+
+func TestToKeyValue(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	kv := ToKeyValue(klog.AString("key", "value"))
+	assert.Equal("key", kv.Key)
+	assert.Equal("value", kv.Value.AsString())
+
+	a := FromKeyValue(otellog.String("key", "value"))
+	assert.Equal("key", a.Key)
+	assert.Equal("value", a.Value.String())
+}
+
+type fakeLoggerProvider struct {
+	embedded.LoggerProvider
+	logger *fakeLogger
+}
+
+func (p *fakeLoggerProvider) Logger(name string, opts ...otellog.LoggerOption) otellog.Logger {
+	return p.logger
+}
+
+type fakeLogger struct {
+	embedded.Logger
+	records []otellog.Record
+}
+
+func (l *fakeLogger) Emit(ctx context.Context, r otellog.Record) {
+	l.records = append(l.records, r)
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+
+	logger := &fakeLogger{}
+	h := NewHandler(&fakeLoggerProvider{logger: logger}, "test")
+
+	sub := h.Subhandler("mod", []klog.Attr{klog.AString("a", "b")})
+	assert.NoError(sub.Handle(context.Background(), klog.NewRecord(time.Now(), klog.LevelInfo, "hello", 0)))
+
+	assert.Len(logger.records, 1)
+	rec := logger.records[0]
+	assert.Equal(otellog.SeverityInfo, rec.Severity())
+	assert.Equal("hello", rec.Body().AsString())
+
+	var gotMod, gotAttr bool
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == "mod" && kv.Value.AsString() == "mod" {
+			gotMod = true
+		}
+		if kv.Key == "a" && kv.Value.AsString() == "b" {
+			gotAttr = true
+		}
+		return true
+	})
+	assert.True(gotMod)
+	assert.True(gotAttr)
+}
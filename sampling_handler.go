@@ -0,0 +1,209 @@
+package klog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// LevelSampleRule configures sampling for a particular [Level]
+	LevelSampleRule struct {
+		Level Level
+		// Burst is the number of events per Tick that always pass
+		Burst uint64
+		// Tick is the duration of the burst window
+		Tick time.Duration
+		// Nth causes only every Nth event past Burst to pass, 0 drops every
+		// event past Burst
+		Nth uint64
+	}
+
+	// SamplingOpt is an options function for [NewSamplingHandler]
+	SamplingOpt = func(h *SamplingHandler)
+
+	samplerState struct {
+		windowStart time.Time
+		count       uint64
+	}
+
+	dedupState struct {
+		windowStart time.Time
+		count       uint64
+	}
+
+	// SamplingHandler wraps a [Handler] and drops or thins high volume log
+	// events
+	SamplingHandler struct {
+		next     Handler
+		clock    Clock
+		rules    map[Level]LevelSampleRule
+		identity func(ctx context.Context, r Record) (string, bool)
+		window   time.Duration
+
+		mu      sync.Mutex
+		samples map[Level]*samplerState
+		dedup   map[string]*dedupState
+	}
+)
+
+// NewSamplingHandler creates a new [*SamplingHandler]
+func NewSamplingHandler(next Handler, opts ...SamplingOpt) *SamplingHandler {
+	h := &SamplingHandler{
+		next:    next,
+		clock:   RealTime{},
+		rules:   map[Level]LevelSampleRule{},
+		window:  time.Second,
+		samples: map[Level]*samplerState{},
+		dedup:   map[string]*dedupState{},
+	}
+	for _, i := range opts {
+		i(h)
+	}
+	return h
+}
+
+// OptSamplingHandler returns a [LoggerOpt] that wraps the current handler in
+// a [*SamplingHandler]
+func OptSamplingHandler(opts ...SamplingOpt) LoggerOpt {
+	return func(l *KLogger) {
+		l.handler = NewSamplingHandler(l.handler, opts...)
+	}
+}
+
+// OptSamplingRule returns a [SamplingOpt] that adds a [LevelSampleRule]
+func OptSamplingRule(rule LevelSampleRule) SamplingOpt {
+	return func(h *SamplingHandler) {
+		h.rules[rule.Level] = rule
+	}
+}
+
+// OptSamplingClock returns a [SamplingOpt] that sets the clock used to track
+// sampling windows
+func OptSamplingClock(c Clock) SamplingOpt {
+	return func(h *SamplingHandler) {
+		h.clock = c
+	}
+}
+
+// OptSamplingIdentity returns a [SamplingOpt] that collapses repeated events
+// sharing the same identity into occurrence counts emitted once per window
+func OptSamplingIdentity(window time.Duration, identity func(ctx context.Context, r Record) (string, bool)) SamplingOpt {
+	return func(h *SamplingHandler) {
+		h.window = window
+		h.identity = identity
+	}
+}
+
+// Enabled implements [Handler]
+func (h *SamplingHandler) Enabled(ctx context.Context, level Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// allowRate reports whether an event at level should pass the burst+tick rule
+// and, for an event passed by the Nth rule, the Nth-1 events dropped just
+// before it in the current Tick. An event passed within Burst reports 0, so
+// events dropped at the end of an earlier Tick are not counted on any passed
+// event.
+func (h *SamplingHandler) allowRate(level Level, now time.Time) (bool, uint64) {
+	rule, ok := h.rules[level]
+	if !ok || rule.Tick <= 0 {
+		return true, 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.samples[level]
+	if !ok {
+		s = &samplerState{windowStart: now}
+		h.samples[level] = s
+	}
+	if now.Sub(s.windowStart) >= rule.Tick {
+		s.windowStart = now
+		s.count = 0
+	}
+	s.count++
+	if s.count <= rule.Burst {
+		return true, 0
+	}
+	if rule.Nth == 0 {
+		return false, 1
+	}
+	past := s.count - rule.Burst
+	if past%rule.Nth == 0 {
+		return true, rule.Nth - 1
+	}
+	return false, 1
+}
+
+// allowIdentity collapses events sharing the same identity, emitting the
+// first event of each window immediately and, on the event that closes a
+// window, emitting that event carrying an aggregated sample.count attr for
+// any duplicates collapsed during the window it closed
+func (h *SamplingHandler) allowIdentity(ctx context.Context, r Record, now time.Time) (Record, bool) {
+	if h.identity == nil {
+		return r, true
+	}
+	key, ok := h.identity(ctx, r)
+	if !ok {
+		return r, true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.evictStaleDedup(now, key)
+
+	d, exists := h.dedup[key]
+	if !exists || now.Sub(d.windowStart) >= h.window {
+		h.dedup[key] = &dedupState{windowStart: now}
+		if exists && d.count > 0 {
+			r.AddAttrs(AUint64("sample.count", d.count))
+		}
+		return r, true
+	}
+	d.count++
+	return r, false
+}
+
+// evictStaleDedup drops dedup entries, other than key, whose window closed
+// more than a full window ago, bounding h.dedup's growth for identities that
+// are never seen again rather than retaining them forever
+func (h *SamplingHandler) evictStaleDedup(now time.Time, key string) {
+	for k, d := range h.dedup {
+		if k != key && now.Sub(d.windowStart) >= 2*h.window {
+			delete(h.dedup, k)
+		}
+	}
+}
+
+// Handle implements [Handler]
+func (h *SamplingHandler) Handle(ctx context.Context, r Record) error {
+	now := h.clock.Time()
+
+	if ok, dropped := h.allowRate(FromSlogLevel(r.Level), now); !ok {
+		return nil
+	} else if dropped > 0 {
+		r.AddAttrs(AUint64("sample.dropped", dropped))
+	}
+
+	rec, ok := h.allowIdentity(ctx, r, now)
+	if !ok {
+		return nil
+	}
+	return h.next.Handle(ctx, rec)
+}
+
+// Subhandler implements [Handler]
+func (h *SamplingHandler) Subhandler(modSegment string, attrs []Attr) Handler {
+	return &SamplingHandler{
+		next:     h.next.Subhandler(modSegment, attrs),
+		clock:    h.clock,
+		rules:    h.rules,
+		identity: h.identity,
+		window:   h.window,
+		samples:  map[Level]*samplerState{},
+		dedup:    map[string]*dedupState{},
+	}
+}
@@ -0,0 +1,112 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bursts then samples every nth event", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		var b bytes.Buffer
+		clock := &testClock{t: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+		h := NewSamplingHandler(
+			NewJSONSlogHandler(NewSyncWriter(&b)),
+			OptSamplingClock(clock),
+			OptSamplingRule(LevelSampleRule{Level: LevelDebug, Burst: 2, Tick: time.Second, Nth: 3}),
+		)
+		l := New(OptHandler(h), OptMinLevel(LevelDebug), OptClock(clock))
+
+		for i := 0; i < 8; i++ {
+			l.Log(context.Background(), LevelDebug, 0, "tick")
+		}
+
+		d := json.NewDecoder(&b)
+		var seen int
+		for d.More() {
+			var j map[string]any
+			assert.NoError(d.Decode(&j))
+			seen++
+		}
+		// 2 burst + 1 event every 3rd of the remaining 6 = 2 more
+		assert.Equal(4, seen)
+	})
+
+	t.Run("collapses repeated identity into occurrence counts", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		var b bytes.Buffer
+		clock := &testClock{t: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+		h := NewSamplingHandler(
+			NewJSONSlogHandler(NewSyncWriter(&b)),
+			OptSamplingClock(clock),
+			OptSamplingIdentity(time.Second, func(ctx context.Context, r Record) (string, bool) {
+				return r.Message, true
+			}),
+		)
+		l := New(OptHandler(h), OptMinLevel(LevelDebug), OptClock(clock))
+
+		l.Log(context.Background(), LevelInfo, 0, "dup", AInt("seq", 0))
+		clock.t = clock.t.Add(100 * time.Millisecond)
+		l.Log(context.Background(), LevelInfo, 0, "dup", AInt("seq", 1))
+		clock.t = clock.t.Add(100 * time.Millisecond)
+		l.Log(context.Background(), LevelInfo, 0, "dup", AInt("seq", 2))
+		clock.t = clock.t.Add(time.Second)
+		l.Log(context.Background(), LevelInfo, 0, "dup", AInt("seq", 3))
+
+		d := json.NewDecoder(&b)
+		d.UseNumber()
+		var events []map[string]any
+		for d.More() {
+			var j map[string]any
+			assert.NoError(d.Decode(&j))
+			events = append(events, j)
+		}
+		// the first event of the first window, then the 4th call itself once it
+		// closes the first window, carrying that window's collapsed count
+		assert.Len(events, 2)
+		assert.NotContains(events[0], "sample.count")
+		assert.Equal(json.Number("0"), events[0]["seq"])
+		assert.Equal(json.Number("2"), events[1]["sample.count"])
+		// the event that closed the window must be the 4th call itself, not a
+		// stale copy of the 1st call's event
+		assert.Equal(json.Number("3"), events[1]["seq"])
+	})
+
+	t.Run("evicts dedup state for identities that are never seen again", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		var b bytes.Buffer
+		clock := &testClock{t: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+		h := NewSamplingHandler(
+			NewJSONSlogHandler(NewSyncWriter(&b)),
+			OptSamplingClock(clock),
+			OptSamplingIdentity(time.Second, func(ctx context.Context, r Record) (string, bool) {
+				return r.Message, true
+			}),
+		)
+		l := New(OptHandler(h), OptMinLevel(LevelDebug), OptClock(clock))
+
+		for i := 0; i < 50; i++ {
+			l.Log(context.Background(), LevelInfo, 0, "distinct message never repeated "+strconv.Itoa(i))
+			clock.t = clock.t.Add(3 * time.Second)
+		}
+
+		assert.Less(len(h.dedup), 50)
+	})
+}
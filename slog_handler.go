@@ -25,55 +25,114 @@ type (
 		FieldMod     string
 		ModSeparator string
 		Mod          string
-		attrKeySet   map[string]struct{}
-		slogHandler  slog.Handler
+		// FieldTraceID is the attr key used for the span trace id found on
+		// the log context, disabled when empty
+		FieldTraceID string
+		// FieldSpanID is the attr key used for the span id found on the log
+		// context, disabled when empty
+		FieldSpanID string
+		// FieldTraceFlags is the attr key used for the span trace flags found
+		// on the log context, disabled when empty
+		FieldTraceFlags string
+		// SpanFromContext returns the span context found on ctx. It is nil by
+		// default, which disables span correlation; the klogotel package's
+		// OptSlogHandlerOtel sets it to klogotel.SpanFromContext
+		SpanFromContext func(ctx context.Context) SpanContext
+		// SpanEventBridge, when non-nil, is called for every record at
+		// [LevelError] or above so the log also shows up on the span timeline
+		SpanEventBridge func(ctx context.Context, r Record)
+		// ErrorMarshaler converts an error attr value into a [slog.Value] for
+		// the underlying slog.Handler, defaulting to [defaultErrorMarshaler]
+		ErrorMarshaler ErrorMarshaler
+		attrKeySet     map[string]struct{}
+		slogHandler    slog.Handler
 	}
+
+	// SpanContext is the subset of an OpenTelemetry span context that
+	// [SlogHandler] needs for trace correlation, avoiding an otel dependency
+	// in this package
+	SpanContext struct {
+		TraceID    string
+		SpanID     string
+		TraceFlags string
+		IsValid    bool
+	}
+
+	// ErrorMarshaler converts an error into a [slog.Value] for logging
+	ErrorMarshaler = func(err error) slog.Value
+
+	// SlogHandlerOpt is an options function for [NewSlogHandler]
+	SlogHandlerOpt = func(h *SlogHandler)
 )
 
 // NewSlogHandler creates a new [*SlogHandler]
-func NewSlogHandler(handler slog.Handler) *SlogHandler {
-	return &SlogHandler{
-		FieldTime:    "t",
-		FieldTimeLoc: time.UTC,
-		FieldSrc:     "src",
-		FieldMod:     "mod",
-		ModSeparator: ".",
-		Mod:          "",
-		attrKeySet:   map[string]struct{}{},
-		slogHandler:  handler,
+func NewSlogHandler(handler slog.Handler, opts ...SlogHandlerOpt) *SlogHandler {
+	h := &SlogHandler{
+		FieldTime:       "t",
+		FieldTimeLoc:    time.UTC,
+		FieldSrc:        "src",
+		FieldMod:        "mod",
+		ModSeparator:    ".",
+		Mod:             "",
+		FieldTraceID:    "trace_id",
+		FieldSpanID:     "span_id",
+		FieldTraceFlags: "trace_flags",
+		ErrorMarshaler:  defaultErrorMarshaler,
+		attrKeySet:      map[string]struct{}{},
+		slogHandler:     handler,
 	}
+	for _, i := range opts {
+		i(h)
+	}
+	return h
 }
 
-func NewTextSlogHandler(w io.Writer) *SlogHandler {
+// OptErrorMarshaler returns a [SlogHandlerOpt] that sets [SlogHandler]
+// ErrorMarshaler
+func OptErrorMarshaler(fn ErrorMarshaler) SlogHandlerOpt {
+	return func(h *SlogHandler) {
+		h.ErrorMarshaler = fn
+	}
+}
+
+func NewTextSlogHandler(w io.Writer, opts ...SlogHandlerOpt) *SlogHandler {
 	return NewSlogHandler(
 		slog.NewTextHandler(w,
 			&slog.HandlerOptions{
 				Level: LevelDebug,
 			},
 		),
+		opts...,
 	)
 }
 
-func NewJSONSlogHandler(w io.Writer) *SlogHandler {
+func NewJSONSlogHandler(w io.Writer, opts ...SlogHandlerOpt) *SlogHandler {
 	return NewSlogHandler(
 		slog.NewJSONHandler(w,
 			&slog.HandlerOptions{
 				Level: LevelDebug,
 			},
 		),
+		opts...,
 	)
 }
 
 func (h *SlogHandler) clone() *SlogHandler {
 	return &SlogHandler{
-		FieldTime:    h.FieldTime,
-		FieldTimeLoc: h.FieldTimeLoc,
-		FieldSrc:     h.FieldSrc,
-		FieldMod:     h.FieldMod,
-		ModSeparator: h.ModSeparator,
-		Mod:          h.Mod,
-		attrKeySet:   maps.Clone(h.attrKeySet),
-		slogHandler:  h.slogHandler,
+		FieldTime:       h.FieldTime,
+		FieldTimeLoc:    h.FieldTimeLoc,
+		FieldSrc:        h.FieldSrc,
+		FieldMod:        h.FieldMod,
+		ModSeparator:    h.ModSeparator,
+		Mod:             h.Mod,
+		FieldTraceID:    h.FieldTraceID,
+		FieldSpanID:     h.FieldSpanID,
+		FieldTraceFlags: h.FieldTraceFlags,
+		SpanFromContext: h.SpanFromContext,
+		SpanEventBridge: h.SpanEventBridge,
+		ErrorMarshaler:  h.ErrorMarshaler,
+		attrKeySet:      maps.Clone(h.attrKeySet),
+		slogHandler:     h.slogHandler,
 	}
 }
 
@@ -98,7 +157,7 @@ func (h *SlogHandler) Enabled(ctx context.Context, level Level) bool {
 }
 
 func (h *SlogHandler) Handle(ctx context.Context, r Record) error {
-	r2 := NewRecord(time.Time{}, r.Level, r.Message, 0)
+	r2 := NewRecord(time.Time{}, FromSlogLevel(r.Level), r.Message, 0)
 	if h.FieldTime != "" && !r.Time.IsZero() {
 		r2.AddAttrs(AString(h.FieldTime, r.Time.In(h.FieldTimeLoc).Format(time.RFC3339Nano)))
 	}
@@ -115,6 +174,22 @@ func (h *SlogHandler) Handle(ctx context.Context, r Record) error {
 	if h.FieldMod != "" && h.Mod != "" {
 		r2.AddAttrs(AString(h.FieldMod, h.Mod))
 	}
+	if h.SpanFromContext != nil {
+		if sc := h.SpanFromContext(ctx); sc.IsValid {
+			if h.FieldTraceID != "" {
+				r2.AddAttrs(AString(h.FieldTraceID, sc.TraceID))
+			}
+			if h.FieldSpanID != "" {
+				r2.AddAttrs(AString(h.FieldSpanID, sc.SpanID))
+			}
+			if h.FieldTraceFlags != "" {
+				r2.AddAttrs(AString(h.FieldTraceFlags, sc.TraceFlags))
+			}
+		}
+	}
+	if h.SpanEventBridge != nil && FromSlogLevel(r.Level) >= LevelError {
+		h.SpanEventBridge(ctx, r)
+	}
 	attrKeys := map[string]struct{}{}
 	addFilteredAttrs := func(attr Attr) bool {
 		if h.checkAttrKey(attr.Key) {
@@ -126,7 +201,7 @@ func (h *SlogHandler) Handle(ctx context.Context, r Record) error {
 		attrKeys[attr.Key] = struct{}{}
 		if attr.Value.Kind() == slog.KindAny {
 			if verr, ok := attr.Value.Any().(error); ok {
-				attr = AAny(attr.Key, errLogValuer{err: verr})
+				attr = AAny(attr.Key, errLogValuer{marshal: h.ErrorMarshaler, err: verr})
 			}
 		}
 		r2.AddAttrs(attr)
@@ -145,12 +220,35 @@ func (h *SlogHandler) Handle(ctx context.Context, r Record) error {
 
 type (
 	errLogValuer struct {
-		err error
+		marshal ErrorMarshaler
+		err     error
 	}
 )
 
 func (e errLogValuer) LogValue() slog.Value {
-	return slog.AnyValue(kerrors.JSONValue(e.err))
+	marshal := e.marshal
+	if marshal == nil {
+		marshal = defaultErrorMarshaler
+	}
+	return marshal(e.err)
+}
+
+// defaultErrorMarshaler is the default [ErrorMarshaler]. It defers to
+// [kerrors.JSONValue] to walk errors.Unwrap/Unwrap() []error chains. Any
+// stack trace present on err is expected to have already been added by
+// [AErr] at the log site, since [ErrorMarshaler] may run later, e.g. on
+// [AsyncHandler]'s background goroutine, where [runtime.Callers] would
+// observe the wrong stack.
+func defaultErrorMarshaler(err error) slog.Value {
+	return slog.AnyValue(kerrors.JSONValue(err))
+}
+
+// AErr returns an [Attr] for err, routing it through the handler's
+// [ErrorMarshaler] when logged. It adds a stack trace via
+// [kerrors.AddStackTrace] if err does not already carry one, capturing it
+// here at the call site rather than later when the [ErrorMarshaler] runs.
+func AErr(key string, err error) Attr {
+	return AAny(key, kerrors.AddStackTrace(err, 1))
 }
 
 func (h *SlogHandler) Subhandler(modSegment string, attrs []Attr) Handler {
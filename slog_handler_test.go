@@ -0,0 +1,30 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptErrorMarshaler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	h := NewJSONSlogHandler(NewSyncWriter(&b), OptErrorMarshaler(func(err error) slog.Value {
+		return slog.StringValue("custom: " + err.Error())
+	}))
+	l := New(OptHandler(h), OptMinLevel(LevelDebug))
+
+	l.Log(context.Background(), LevelError, 0, "failed", AErr("err", errors.New("boom")))
+
+	var j map[string]any
+	assert.NoError(json.Unmarshal(b.Bytes(), &j))
+	assert.Equal("custom: boom", j["err"])
+}
@@ -0,0 +1,232 @@
+// Package slogbridge converts between klog's attribute value model and the
+// standard library's [log/slog] package, so a codebase that already plumbs
+// *slog.Logger around a call tree can adopt klog without rewriting call
+// sites, and vice versa.
+package slogbridge
+
+import (
+	"context"
+	"log/slog"
+
+	expslog "golang.org/x/exp/slog"
+	"xorkevin.dev/klog"
+)
+
+// ToSlogValue converts a [klog.Value] to an [slog.Value], preserving Kind,
+// including groups and unresolved [klog.LogValuer] chains
+func ToSlogValue(v klog.Value) slog.Value {
+	switch v.Kind() {
+	case klog.KindBool:
+		return slog.BoolValue(v.Bool())
+	case klog.KindInt64:
+		return slog.Int64Value(v.Int64())
+	case klog.KindUint64:
+		return slog.Uint64Value(v.Uint64())
+	case klog.KindFloat64:
+		return slog.Float64Value(v.Float64())
+	case klog.KindString:
+		return slog.StringValue(v.StringValue())
+	case klog.KindTime:
+		return slog.TimeValue(v.Time())
+	case klog.KindDuration:
+		return slog.DurationValue(v.Duration())
+	case klog.KindGroup:
+		g := v.Group()
+		out := make([]slog.Attr, len(g))
+		for i, a := range g {
+			out[i] = ToSlogAttr(a)
+		}
+		return slog.GroupValue(out...)
+	case klog.KindLogValuer:
+		return slog.AnyValue(klogValuerValue{lv: v.LogValuer()})
+	default:
+		return slog.AnyValue(v.Any())
+	}
+}
+
+// FromSlogValue converts an [slog.Value] to a [klog.Value], preserving Kind,
+// including groups and unresolved [slog.LogValuer] chains
+func FromSlogValue(v slog.Value) klog.Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return klog.ValueBool(v.Bool())
+	case slog.KindInt64:
+		return klog.ValueInt64(v.Int64())
+	case slog.KindUint64:
+		return klog.ValueUint64(v.Uint64())
+	case slog.KindFloat64:
+		return klog.ValueFloat64(v.Float64())
+	case slog.KindString:
+		return klog.ValueString(v.String())
+	case slog.KindTime:
+		return klog.ValueTime(v.Time())
+	case slog.KindDuration:
+		return klog.ValueDuration(v.Duration())
+	case slog.KindGroup:
+		g := v.Group()
+		out := make([]klog.Attr, len(g))
+		for i, a := range g {
+			out[i] = FromSlogAttr(a)
+		}
+		return klog.ValueGroup(out...)
+	case slog.KindLogValuer:
+		return klog.ValueAny(slogValuerValue{lv: v.LogValuer()})
+	default:
+		return klog.ValueAny(v.Any())
+	}
+}
+
+// ToSlogAttr converts a [klog.Attr] (an [expslog.Attr]) to an [slog.Attr]
+func ToSlogAttr(a klog.Attr) slog.Attr {
+	return slog.Attr{Key: a.Key, Value: toSlogValueFromExp(a.Value)}
+}
+
+// FromSlogAttr converts an [slog.Attr] to a [klog.Attr] (an [expslog.Attr])
+func FromSlogAttr(a slog.Attr) klog.Attr {
+	return expslog.Attr{Key: a.Key, Value: fromSlogValueToExp(a.Value)}
+}
+
+// toSlogValueFromExp converts the value on a [klog.Attr], which is an
+// [expslog.Value] rather than a [klog.Value], to an [slog.Value]. klog
+// routes its frontend attrs through [expslog.Attr] rather than through
+// value.go's Value, so this conversion is distinct from [ToSlogValue].
+func toSlogValueFromExp(v expslog.Value) slog.Value {
+	switch v.Kind() {
+	case expslog.KindBool:
+		return slog.BoolValue(v.Bool())
+	case expslog.KindInt64:
+		return slog.Int64Value(v.Int64())
+	case expslog.KindUint64:
+		return slog.Uint64Value(v.Uint64())
+	case expslog.KindFloat64:
+		return slog.Float64Value(v.Float64())
+	case expslog.KindString:
+		return slog.StringValue(v.String())
+	case expslog.KindTime:
+		return slog.TimeValue(v.Time())
+	case expslog.KindDuration:
+		return slog.DurationValue(v.Duration())
+	case expslog.KindGroup:
+		g := v.Group()
+		out := make([]slog.Attr, len(g))
+		for i, a := range g {
+			out[i] = ToSlogAttr(a)
+		}
+		return slog.GroupValue(out...)
+	case expslog.KindLogValuer:
+		return slog.AnyValue(expValuerValue{lv: v.LogValuer()})
+	default:
+		return slog.AnyValue(v.Any())
+	}
+}
+
+func fromSlogValueToExp(v slog.Value) expslog.Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return expslog.BoolValue(v.Bool())
+	case slog.KindInt64:
+		return expslog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return expslog.Uint64Value(v.Uint64())
+	case slog.KindFloat64:
+		return expslog.Float64Value(v.Float64())
+	case slog.KindString:
+		return expslog.StringValue(v.String())
+	case slog.KindTime:
+		return expslog.TimeValue(v.Time())
+	case slog.KindDuration:
+		return expslog.DurationValue(v.Duration())
+	case slog.KindGroup:
+		g := v.Group()
+		out := make([]expslog.Attr, len(g))
+		for i, a := range g {
+			out[i] = FromSlogAttr(a)
+		}
+		return expslog.GroupValue(out...)
+	case slog.KindLogValuer:
+		return expslog.AnyValue(stdValuerValue{lv: v.LogValuer()})
+	default:
+		return expslog.AnyValue(v.Any())
+	}
+}
+
+type (
+	// klogValuerValue bridges a [klog.LogValuer] into an [slog.LogValuer] so
+	// stdlib slog's own Resolve resolves the chain lazily
+	klogValuerValue struct {
+		lv klog.LogValuer
+	}
+
+	// slogValuerValue bridges an [slog.LogValuer] into a [klog.LogValuer]
+	slogValuerValue struct {
+		lv slog.LogValuer
+	}
+
+	// expValuerValue bridges an [expslog.LogValuer] into an [slog.LogValuer]
+	expValuerValue struct {
+		lv expslog.LogValuer
+	}
+
+	// stdValuerValue bridges an [slog.LogValuer] into an [expslog.LogValuer]
+	stdValuerValue struct {
+		lv slog.LogValuer
+	}
+)
+
+func (v klogValuerValue) LogValue() slog.Value {
+	return ToSlogValue(v.lv.LogValue())
+}
+
+func (v slogValuerValue) LogValue() klog.Value {
+	return FromSlogValue(v.lv.LogValue())
+}
+
+func (v expValuerValue) LogValue() slog.Value {
+	return toSlogValueFromExp(v.lv.LogValue())
+}
+
+func (v stdValuerValue) LogValue() expslog.Value {
+	return fromSlogValueToExp(v.lv.LogValue())
+}
+
+// SlogHandler adapts a [klog.Handler] to the standard library's
+// [slog.Handler] interface, so klog can act as the backend for a *slog.Logger
+type SlogHandler struct {
+	h klog.Handler
+}
+
+// NewSlogHandler creates a new [*SlogHandler]
+func NewSlogHandler(h klog.Handler) *SlogHandler {
+	return &SlogHandler{h: h}
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, klog.FromSlogLevel(expslog.Level(level)))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	rec := klog.NewRecord(r.Time, klog.FromSlogLevel(expslog.Level(r.Level)), r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		rec.AddAttrs(FromSlogAttr(a))
+		return true
+	})
+	return h.h.Handle(ctx, rec)
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	kattrs := make([]klog.Attr, len(attrs))
+	for i, a := range attrs {
+		kattrs[i] = FromSlogAttr(a)
+	}
+	return &SlogHandler{h: h.h.Subhandler("", kattrs)}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{h: h.h.Subhandler(name, nil)}
+}
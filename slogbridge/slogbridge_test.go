@@ -0,0 +1,86 @@
+package slogbridge
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	expslog "golang.org/x/exp/slog"
+	"xorkevin.dev/klog"
+)
+
+func TestValueRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	now := time.Now().In(time.UTC)
+	v := klog.ValueGroup(
+		klog.AString("s", "hello"),
+		klog.AInt64("i", 42),
+		klog.ATime("t", now),
+	)
+
+	sv := ToSlogValue(v)
+	assert.Equal(slog.KindGroup, sv.Kind())
+
+	back := FromSlogValue(sv)
+	assert.Equal(klog.KindGroup, back.Kind())
+	g := back.Group()
+	assert.Len(g, 3)
+	assert.Equal("hello", g[0].Value.String())
+	assert.Equal(int64(42), g[1].Value.Int64())
+	assert.True(now.Equal(g[2].Value.Time()))
+}
+
+type testLogValuer struct{}
+
+func (testLogValuer) LogValue() klog.Value {
+	return klog.ValueString("resolved")
+}
+
+func TestLogValuerBridge(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ToSlogValue(klog.ValueAny(testLogValuer{}))
+	assert.Equal(slog.KindLogValuer, v.Kind())
+	assert.Equal("resolved", v.Resolve().String())
+}
+
+func TestSlogHandlerAdapter(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var got expslog.Record
+	h := NewSlogHandler(fakeKlogHandler{onHandle: func(r expslog.Record) {
+		got = r
+	}})
+
+	l := slog.New(h)
+	l.Info("hello", "key", "value")
+
+	assert.Equal("hello", got.Message)
+	assert.Equal(1, got.NumAttrs())
+}
+
+type fakeKlogHandler struct {
+	onHandle func(r expslog.Record)
+}
+
+func (h fakeKlogHandler) Enabled(ctx context.Context, level klog.Level) bool {
+	return true
+}
+
+func (h fakeKlogHandler) Handle(ctx context.Context, r expslog.Record) error {
+	h.onHandle(r)
+	return nil
+}
+
+func (h fakeKlogHandler) Subhandler(modSegment string, attrs []expslog.Attr) klog.Handler {
+	return h
+}
@@ -0,0 +1,175 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var levelColors = map[Level]string{
+	LevelTrace: "\x1b[90m", // bright black
+	LevelDebug: "\x1b[36m", // cyan
+	LevelInfo:  "\x1b[32m", // green
+	LevelWarn:  "\x1b[33m", // yellow
+	LevelError: "\x1b[31m", // red
+	LevelFatal: "\x1b[35m", // magenta
+}
+
+const ansiReset = "\x1b[0m"
+
+// terminalMessageWidth is the minimum width the message is right-padded to,
+// so that attr columns line up across log lines
+const terminalMessageWidth = 40
+
+type (
+	// TerminalHandler writes human friendly, optionally colorized single-line
+	// logs intended for a TTY
+	TerminalHandler struct {
+		FieldTimeLoc *time.Location
+		FieldMod     string
+		ModSeparator string
+		Mod          string
+		MinLevel     Level
+		W            io.Writer
+		// Color enables ANSI coloring of the level and message when true
+		Color bool
+		// IsTerminal reports whether W is a terminal and is used by
+		// [NewTerminalHandler] to set the initial value of Color. Kept as an
+		// injected func so this package stays free of syscall dependencies.
+		IsTerminal  func() bool
+		attrKeySet  map[string]struct{}
+		presetAttrs []Attr
+	}
+)
+
+// NewTerminalHandler creates a new [*TerminalHandler]. Color defaults to w
+// being a terminal as reported by isTerminal, or true if isTerminal is nil.
+func NewTerminalHandler(w io.Writer, isTerminal func() bool) *TerminalHandler {
+	return &TerminalHandler{
+		FieldTimeLoc: time.Local,
+		FieldMod:     "mod",
+		ModSeparator: ".",
+		Mod:          "",
+		MinLevel:     LevelDebug,
+		W:            w,
+		Color:        isTerminal == nil || isTerminal(),
+		IsTerminal:   isTerminal,
+		attrKeySet:   map[string]struct{}{},
+	}
+}
+
+func (h *TerminalHandler) clone() *TerminalHandler {
+	return &TerminalHandler{
+		FieldTimeLoc: h.FieldTimeLoc,
+		FieldMod:     h.FieldMod,
+		ModSeparator: h.ModSeparator,
+		Mod:          h.Mod,
+		MinLevel:     h.MinLevel,
+		W:            h.W,
+		Color:        h.Color,
+		IsTerminal:   h.IsTerminal,
+		attrKeySet:   cloneAttrKeySet(h.attrKeySet),
+		presetAttrs:  append([]Attr{}, h.presetAttrs...),
+	}
+}
+
+func (h *TerminalHandler) checkAttrKey(k string) bool {
+	if k == "" {
+		return true
+	}
+	if k == h.FieldMod {
+		return true
+	}
+	if _, ok := h.attrKeySet[k]; ok {
+		return true
+	}
+	return false
+}
+
+// Enabled implements [Handler]
+func (h *TerminalHandler) Enabled(ctx context.Context, level Level) bool {
+	return level >= h.MinLevel
+}
+
+// Handle implements [Handler]
+func (h *TerminalHandler) Handle(ctx context.Context, r Record) error {
+	colorOn, colorOff := "", ""
+	if h.Color {
+		if c, ok := levelColors[FromSlogLevel(r.Level)]; ok {
+			colorOn, colorOff = c, ansiReset
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(r.Time.In(h.FieldTimeLoc).Format("2006-01-02T15:04:05.000"))
+	buf.WriteByte(' ')
+	fmt.Fprintf(&buf, "%s%-5s%s", colorOn, FromSlogLevel(r.Level).String(), colorOff)
+	buf.WriteByte(' ')
+	if h.FieldMod != "" && h.Mod != "" {
+		buf.WriteByte('[')
+		buf.WriteString(h.Mod)
+		buf.WriteString("] ")
+	}
+	fmt.Fprintf(&buf, "%s%-*s%s", colorOn, terminalMessageWidth, r.Message, colorOff)
+
+	attrKeys := map[string]struct{}{}
+	addFilteredAttrs := func(attr Attr) bool {
+		if h.checkAttrKey(attr.Key) {
+			return true
+		}
+		if _, ok := attrKeys[attr.Key]; ok {
+			return true
+		}
+		attrKeys[attr.Key] = struct{}{}
+		writeLogfmtAttr(&buf, attr.Key, attr.Value)
+		return true
+	}
+	for _, attr := range h.presetAttrs {
+		writeLogfmtAttr(&buf, attr.Key, attr.Value)
+	}
+	for ctxAttrs := getCtxAttrs(ctx); ctxAttrs != nil; ctxAttrs = ctxAttrs.parent {
+		ctxAttrs.attrs.readAttrs(addFilteredAttrs)
+	}
+	r.Attrs(addFilteredAttrs)
+
+	if r.PC != 0 {
+		frame := linecaller(r.PC)
+		buf.WriteByte(' ')
+		buf.WriteString(truncatedCaller(frame.File, frame.Line))
+	}
+
+	buf.WriteByte('\n')
+	_, err := h.W.Write(buf.Bytes())
+	return err
+}
+
+// truncatedCaller renders file:line truncated to its containing package
+// directory and file, e.g. "pkg/file.go:42"
+func truncatedCaller(file string, line int) string {
+	if idx := strings.LastIndex(file, "/"); idx >= 0 {
+		if idx2 := strings.LastIndex(file[:idx], "/"); idx2 >= 0 {
+			file = file[idx2+1:]
+		}
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
+// Subhandler implements [Handler]
+func (h *TerminalHandler) Subhandler(modSegment string, attrs []Attr) Handler {
+	h2 := h.clone()
+	if modSegment != "" {
+		h2.Mod += h2.ModSeparator + modSegment
+	}
+	for _, i := range attrs {
+		if h2.checkAttrKey(i.Key) {
+			continue
+		}
+		h2.attrKeySet[i.Key] = struct{}{}
+		h2.presetAttrs = append(h2.presetAttrs, i)
+	}
+	return h2
+}
@@ -0,0 +1,59 @@
+package klog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	h := NewTerminalHandler(NewSyncWriter(&b), nil)
+	h.Color = false
+	var l Logger = New(OptHandler(h), OptMinLevel(LevelDebug))
+	l = l.Sublogger("mod")
+
+	l.Log(context.Background(), LevelWarn, 0, "disk usage high", AString("pct", "91"))
+
+	line := b.String()
+	assert.True(strings.Contains(line, "WARN "))
+	assert.True(strings.Contains(line, "[.mod]"))
+	assert.True(strings.Contains(line, "disk usage high"))
+	assert.True(strings.Contains(line, "pct=91"))
+	assert.True(strings.Contains(line, "terminal_handler_test.go:"))
+}
+
+func TestTerminalHandlerAlignsMessage(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	h := NewTerminalHandler(NewSyncWriter(&b), nil)
+	h.Color = false
+	l := New(OptHandler(h), OptMinLevel(LevelDebug))
+
+	l.Log(context.Background(), LevelInfo, 0, "short", AString("a", "1"))
+
+	line := b.String()
+	idx := strings.Index(line, "short")
+	assert.GreaterOrEqual(len(line), idx+terminalMessageWidth)
+	assert.True(strings.HasPrefix(strings.TrimLeft(line[idx+terminalMessageWidth:], " "), "a=1"))
+}
+
+func TestTerminalHandlerAutoDisablesColor(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	var b bytes.Buffer
+	h := NewTerminalHandler(NewSyncWriter(&b), func() bool { return false })
+	assert.False(h.Color)
+}
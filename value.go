@@ -4,19 +4,42 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"strconv"
 	"time"
+
+	"golang.org/x/exp/slog"
 )
 
 type (
 	// Kind is the kind of an attribute value
 	Kind int
 
-	// Value is an attribute value
+	// Value is an attribute value. The zero Value corresponds to nil.
+	//
+	// Value is a standalone value model: [Attr] is an alias for
+	// [slog.Attr], so a [Record]'s attrs carry [slog.Value], and handlers
+	// in this package (e.g. [LogfmtHandler], [TerminalHandler]) resolve
+	// those through slog's own Value.Resolve, not through [Value.Resolve]
+	// or [Value.Walk]. Value and its LogValuer/cycle/recursion-limit
+	// handling are exported for code that builds or walks values
+	// independently of a [Handler], such as [slogbridge]'s conversions.
+	//
+	// num holds the value for Kinds Bool, Int64, Uint64, Float64, and
+	// Duration, the string or group length for KindString and KindGroup
+	// (unsafe build) or is unused (safe build), and nanoseconds since the
+	// epoch for KindTime.
+	//
+	// If any is of type [kind], the value is in num as described above. If
+	// any is of type timeLocation, the Kind is KindTime and the time.Time is
+	// reconstructed from the Unix nanos in num and the location (monotonic
+	// time is not preserved). If any is of type bytesHolder, the Kind is
+	// KindBytes. Otherwise any holds the string or group storage described
+	// in value_safe.go/value_unsafe.go, the [LogValuer] itself for
+	// KindLogValuer, or the raw value for KindAny.
 	Value struct {
-		kind Kind
-		n    uint64
-		s    string
-		v    any
+		num uint64
+		any any
 	}
 
 	// LogValuer returns a Value for logging
@@ -35,8 +58,45 @@ const (
 	KindString
 	KindTime
 	KindDuration
+	KindGroup
+	KindLogValuer
+	KindBytes
 )
 
+var kindStrings = [...]string{
+	KindAny:       "Any",
+	KindBool:      "Bool",
+	KindInt64:     "Int64",
+	KindUint64:    "Uint64",
+	KindFloat64:   "Float64",
+	KindString:    "String",
+	KindTime:      "Time",
+	KindDuration:  "Duration",
+	KindGroup:     "Group",
+	KindLogValuer: "LogValuer",
+	KindBytes:     "Bytes",
+}
+
+// String implements [fmt.Stringer]
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindStrings) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindStrings[k]
+}
+
+// kind wraps [Kind] so it may be stored in Value.any to mark a value that
+// lives entirely in Value.num. No user-provided value has this type.
+type kind Kind
+
+// timeLocation wraps *[time.Location] so it may be stored in Value.any to
+// mark a [KindTime] value. No user-provided value has this type.
+type timeLocation *time.Location
+
+// bytesHolder wraps []byte so it may be stored in Value.any to mark a
+// [KindBytes] value.
+type bytesHolder []byte
+
 func ValueAny(v any) Value {
 	switch v := v.(type) {
 	case bool:
@@ -71,20 +131,16 @@ func ValueAny(v any) Value {
 		return ValueTime(v)
 	case time.Duration:
 		return ValueDuration(v)
+	case []Attr:
+		return ValueGroup(v...)
+	case []byte:
+		return ValueBytes(v)
 	case Value:
 		return v
+	case LogValuer:
+		return Value{any: v}
 	default:
-		return Value{
-			kind: KindAny,
-			v:    v,
-		}
-	}
-}
-
-func ValueGroup(v ...Attr) Value {
-	return Value{
-		kind: KindAny,
-		v:    v,
+		return Value{any: v}
 	}
 }
 
@@ -94,8 +150,8 @@ func ValueBool(v bool) Value {
 		n = 1
 	}
 	return Value{
-		kind: KindBool,
-		n:    n,
+		num: n,
+		any: kind(KindBool),
 	}
 }
 
@@ -105,63 +161,67 @@ func ValueInt(v int) Value {
 
 func ValueInt64(v int64) Value {
 	return Value{
-		kind: KindInt64,
-		n:    uint64(v),
+		num: uint64(v),
+		any: kind(KindInt64),
 	}
 }
 
 func ValueUint64(v uint64) Value {
 	return Value{
-		kind: KindUint64,
-		n:    v,
+		num: v,
+		any: kind(KindUint64),
 	}
 }
 
 func ValueFloat64(v float64) Value {
 	return Value{
-		kind: KindFloat64,
-		n:    math.Float64bits(v),
-	}
-}
-
-func ValueString(v string) Value {
-	return Value{
-		kind: KindString,
-		s:    v,
+		num: math.Float64bits(v),
+		any: kind(KindFloat64),
 	}
 }
 
+// ValueTime returns a [Value] of [KindTime]. It discards the monotonic
+// portion of v.
 func ValueTime(v time.Time) Value {
+	if v.IsZero() {
+		// UnixNano on the zero time is undefined, so represent the zero
+		// time with a nil location instead. time.Time.Location never
+		// returns nil, so this cannot be mistaken for any other Value.
+		return Value{any: timeLocation(nil)}
+	}
 	return Value{
-		kind: KindTime,
-		v:    v,
+		num: uint64(v.UnixNano()),
+		any: timeLocation(v.Location()),
 	}
 }
 
 func ValueDuration(v time.Duration) Value {
 	return Value{
-		kind: KindDuration,
-		n:    uint64(v),
+		num: uint64(v),
+		any: kind(KindDuration),
 	}
 }
 
+// ValueBytes returns a [Value] of [KindBytes]. The caller must not
+// subsequently mutate v.
+func ValueBytes(v []byte) Value {
+	return Value{any: bytesHolder(v)}
+}
+
 func (v Value) Kind() Kind {
-	switch v.kind {
-	case KindBool:
-		return KindBool
-	case KindInt64:
-		return KindInt64
-	case KindUint64:
-		return KindUint64
-	case KindFloat64:
-		return KindFloat64
-	case KindString:
-		return KindString
-	case KindTime:
+	switch x := v.any.(type) {
+	case kind:
+		return Kind(x)
+	case timeLocation:
 		return KindTime
-	case KindDuration:
-		return KindDuration
+	case bytesHolder:
+		return KindBytes
+	case LogValuer:
+		return KindLogValuer
 	default:
+		if k, ok := extKind(x); ok {
+			return k
+		}
 		return KindAny
 	}
 }
@@ -182,41 +242,129 @@ func (v Value) Any() any {
 		return v.Time()
 	case KindDuration:
 		return v.Duration()
+	case KindGroup:
+		return v.Group()
+	case KindLogValuer:
+		return v.LogValuer()
+	case KindBytes:
+		return v.Bytes()
 	default:
-		return v.v
+		return v.any
 	}
 }
 
 func (v Value) Bool() bool {
-	return v.n != 0
+	return v.num != 0
 }
 
 func (v Value) Int64() int64 {
-	return int64(v.n)
+	return int64(v.num)
 }
 
 func (v Value) Uint64() uint64 {
-	return v.n
+	return v.num
 }
 
 func (v Value) Float64() float64 {
-	return math.Float64frombits(v.n)
-}
-
-func (v Value) StringValue() string {
-	return v.s
+	return math.Float64frombits(v.num)
 }
 
 func (v Value) Time() time.Time {
-	t, ok := v.v.(time.Time)
-	if !ok {
+	loc, ok := v.any.(timeLocation)
+	if !ok || loc == nil {
 		return time.Time{}
 	}
-	return t
+	return time.Unix(0, int64(v.num)).In(loc)
 }
 
 func (v Value) Duration() time.Duration {
-	return time.Duration(v.n)
+	return time.Duration(v.num)
+}
+
+// Bytes returns the value of a [KindBytes] value, or nil otherwise
+func (v Value) Bytes() []byte {
+	b, ok := v.any.(bytesHolder)
+	if !ok {
+		return nil
+	}
+	return []byte(b)
+}
+
+// LogValuer returns the [LogValuer] of a [KindLogValuer] value, or nil
+// otherwise
+func (v Value) LogValuer() LogValuer {
+	k, ok := v.any.(LogValuer)
+	if !ok {
+		return nil
+	}
+	return k
+}
+
+// Equal reports whether v and o represent the same value. Values of
+// [KindAny] fall back to [reflect.DeepEqual], since their underlying any may
+// not be comparable with ==.
+func (v Value) Equal(o Value) bool {
+	k1 := v.Kind()
+	k2 := o.Kind()
+	if k1 != k2 {
+		return false
+	}
+	switch k1 {
+	case KindBool, KindInt64, KindUint64, KindFloat64, KindDuration:
+		return v.num == o.num
+	case KindString:
+		return v.StringValue() == o.StringValue()
+	case KindTime:
+		return v.Time().Equal(o.Time())
+	case KindBytes:
+		return string(v.Bytes()) == string(o.Bytes())
+	case KindGroup:
+		// Attr (an [expslog.Attr]) already has an Equal method, since Attr is
+		// an alias rather than a type defined in this package.
+		g1, g2 := v.Group(), o.Group()
+		if len(g1) != len(g2) {
+			return false
+		}
+		for i := range g1 {
+			if !g1[i].Equal(g2[i]) {
+				return false
+			}
+		}
+		return true
+	case KindLogValuer:
+		return v.LogValuer() == o.LogValuer()
+	default:
+		return reflect.DeepEqual(v.any, o.any)
+	}
+}
+
+// AppendFormat appends a text representation of v to buf without going
+// through fmt, as with [fmt.Sprint], except that KindBytes appends the raw
+// bytes, and KindGroup, KindAny and KindLogValuer fall back to fmt.Append. verb is currently unused and reserved for a
+// future %q/%v-style format selector.
+func (v Value) AppendFormat(buf []byte, verb byte) []byte {
+	switch v.Kind() {
+	case KindBool:
+		return strconv.AppendBool(buf, v.Bool())
+	case KindInt64:
+		return strconv.AppendInt(buf, v.Int64(), 10)
+	case KindUint64:
+		return strconv.AppendUint(buf, v.Uint64(), 10)
+	case KindFloat64:
+		return strconv.AppendFloat(buf, v.Float64(), 'g', -1, 64)
+	case KindString:
+		return append(buf, v.StringValue()...)
+	case KindTime:
+		return append(buf, v.Time().String()...)
+	case KindDuration:
+		return append(buf, v.Duration().String()...)
+	case KindBytes:
+		return append(buf, v.Bytes()...)
+	case KindGroup:
+		return fmt.Append(buf, v.Group())
+	default:
+		return fmt.Append(buf, v.Any())
+	}
 }
 
 const (
@@ -227,44 +375,207 @@ var (
 	// ErrorExceedValueResolveRecursion is returned as a log value when Resolve
 	// exceeds recursion limits.
 	ErrorExceedValueResolveRecursion = errors.New("Exceeded value resolve recursion")
+
+	// ErrorResolveCycle is returned as a log value when Resolve detects a
+	// [LogValuer] that resolves back to itself, directly or transitively.
+	ErrorResolveCycle = errors.New("Cycle detected resolving value")
 )
 
-// Resolve recursively calls [LogValuer] LogValue up to a recursion limit. It
-// resolves group value attributes recursively up to a recursion limit.
+// ResolveError is the [Value] returned in place of one that failed to
+// resolve, either by exceeding the recursion limit or by forming a cycle. It
+// implements both error and [LogValuer], returning a safe string
+// representation of itself, so a malformed chain can never itself fail to
+// resolve.
+type ResolveError struct {
+	Err   error
+	Value any
+}
+
+// Error implements error
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("%s: %T", e.Err, e.Value)
+}
+
+// Unwrap implements [errors.Unwrap]
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// LogValue implements [LogValuer]
+func (e *ResolveError) LogValue() Value {
+	return ValueString(e.Error())
+}
+
+// Resolve calls [LogValuer] LogValue up to a recursion limit, and resolves
+// group value attributes recursively up to the same limit. See
+// [Value.ResolveWithLimit] for details.
 func (v Value) Resolve() Value {
-	return v.resolveGroup(valueResolveRecursionLimit)
+	return v.ResolveWithLimit(valueResolveRecursionLimit)
 }
 
-func (v Value) resolveGroup(depth int) Value {
-	v = v.resolveValue()
-	if v.kind != KindAny {
+// ResolveWithLimit resolves v like [Value.Resolve], but with an explicit
+// recursion limit on both [LogValuer] chain length and group nesting depth.
+// Group nesting is walked with an explicit stack rather than recursion, so a
+// deeply nested group cannot overflow the Go call stack. A [LogValuer] chain
+// that revisits a value it has already seen is reported as a
+// [*ResolveError] wrapping [ErrorResolveCycle] instead of looping forever;
+// one that runs past limit steps is reported as a [*ResolveError] wrapping
+// [ErrorExceedValueResolveRecursion].
+func (v Value) ResolveWithLimit(limit int) Value {
+	v = resolveLogValuer(v, limit)
+	if v.Kind() != KindGroup {
 		return v
 	}
-	k, ok := v.v.([]Attr)
-	if !ok {
-		return v
+
+	type frame struct {
+		attrs     []Attr
+		idx       int
+		remaining int
 	}
-	for i, a := range k {
-		if depth < 0 {
-			k[i].Value = ValueAny(fmt.Errorf("%w: group value depth"))
-		} else {
-			k[i].Value = a.Value.resolveGroup(depth - 1)
+
+	stack := []frame{{attrs: v.Group(), remaining: limit}}
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if f.idx >= len(f.attrs) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		a := &f.attrs[f.idx]
+		f.idx++
+
+		if f.remaining < 0 {
+			a.Value = valueToAttrValue(ValueAny(&ResolveError{
+				Err:   ErrorExceedValueResolveRecursion,
+				Value: valueFromAttrValue(a.Value).Any(),
+			}))
+			continue
+		}
+
+		resolved := resolveLogValuer(valueFromAttrValue(a.Value), limit)
+		a.Value = valueToAttrValue(resolved)
+		if resolved.Kind() == KindGroup {
+			stack = append(stack, frame{attrs: resolved.Group(), remaining: f.remaining - 1})
 		}
 	}
 	return v
 }
 
-func (v Value) resolveValue() Value {
-	orig := v
-	for i := 0; i < valueResolveRecursionLimit; i++ {
-		if v.kind != KindAny {
+// Walk performs a depth-first traversal of v, resolving [LogValuer] chains
+// and descending into [KindGroup] values as it goes, and calls f once for
+// every leaf value. path holds the sequence of group keys leading to that
+// leaf, and is only valid for the duration of the call. Walk stops and
+// returns the first non-nil error returned by f.
+func (v Value) Walk(f func(path []string, leaf Value) error) error {
+	return v.walk(nil, valueResolveRecursionLimit, f)
+}
+
+func (v Value) walk(path []string, limit int, f func(path []string, leaf Value) error) error {
+	v = resolveLogValuer(v, limit)
+	if v.Kind() != KindGroup {
+		return f(path, v)
+	}
+	if limit < 0 {
+		return f(path, ValueAny(&ResolveError{Err: ErrorExceedValueResolveRecursion, Value: v.Any()}))
+	}
+	for _, a := range v.Group() {
+		next := make([]string, len(path)+1)
+		copy(next, path)
+		next[len(path)] = a.Key
+		if err := valueFromAttrValue(a.Value).walk(next, limit-1, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLogValuer resolves a single [LogValuer] chain (without descending
+// into groups) up to limit steps, detecting cycles via a visited set of the
+// [LogValuer] values seen so far. Like [Value.Equal] on [KindLogValuer], this
+// may panic if a LogValuer in the chain is not comparable.
+func resolveLogValuer(v Value, limit int) Value {
+	var seen map[LogValuer]struct{}
+	for {
+		if v.Kind() != KindLogValuer {
 			return v
 		}
-		k, ok := v.v.(LogValuer)
-		if !ok {
+		if limit <= 0 {
+			return ValueAny(&ResolveError{Err: ErrorExceedValueResolveRecursion, Value: v.Any()})
+		}
+		limit--
+		lv := v.LogValuer()
+		if lv == nil {
 			return v
 		}
-		v = k.LogValue()
+		if seen == nil {
+			seen = make(map[LogValuer]struct{}, 1)
+		}
+		if _, ok := seen[lv]; ok {
+			return ValueAny(&ResolveError{Err: ErrorResolveCycle, Value: lv})
+		}
+		seen[lv] = struct{}{}
+		v = lv.LogValue()
+	}
+}
+
+// attrLogValuerBridge adapts an [slog.LogValuer] held by a [klog.Attr] into
+// a [LogValuer], so resolving across the Attr/Value boundary can share
+// [resolveLogValuer] instead of duplicating its cycle and limit handling.
+type attrLogValuerBridge struct {
+	lv slog.LogValuer
+}
+
+func (b attrLogValuerBridge) LogValue() Value {
+	return valueFromAttrValue(b.lv.LogValue())
+}
+
+// valueFromAttrValue converts the value held by a [klog.Attr], which is an
+// [slog.Value] rather than a [Value] (klog.Attr is an alias of
+// [slog.Attr]), into a [Value].
+func valueFromAttrValue(v slog.Value) Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return ValueBool(v.Bool())
+	case slog.KindInt64:
+		return ValueInt64(v.Int64())
+	case slog.KindUint64:
+		return ValueUint64(v.Uint64())
+	case slog.KindFloat64:
+		return ValueFloat64(v.Float64())
+	case slog.KindString:
+		return ValueString(v.String())
+	case slog.KindTime:
+		return ValueTime(v.Time())
+	case slog.KindDuration:
+		return ValueDuration(v.Duration())
+	case slog.KindGroup:
+		return ValueGroup(v.Group()...)
+	case slog.KindLogValuer:
+		return Value{any: attrLogValuerBridge{lv: v.LogValuer()}}
+	default:
+		return ValueAny(v.Any())
+	}
+}
+
+// valueToAttrValue is the inverse of [valueFromAttrValue]
+func valueToAttrValue(v Value) slog.Value {
+	switch v.Kind() {
+	case KindBool:
+		return slog.BoolValue(v.Bool())
+	case KindInt64:
+		return slog.Int64Value(v.Int64())
+	case KindUint64:
+		return slog.Uint64Value(v.Uint64())
+	case KindFloat64:
+		return slog.Float64Value(v.Float64())
+	case KindString:
+		return slog.StringValue(v.StringValue())
+	case KindTime:
+		return slog.TimeValue(v.Time())
+	case KindDuration:
+		return slog.DurationValue(v.Duration())
+	case KindGroup:
+		return slog.GroupValue(v.Group()...)
+	default:
+		return slog.AnyValue(v.Any())
 	}
-	return ValueAny(fmt.Errorf("%w: value type %T", orig.v))
 }
@@ -0,0 +1,51 @@
+//go:build klog_safe
+
+// This file is the klog_safe counterpart to value_unsafe.go, for callers
+// that disallow the unsafe package. It trades the zero-allocation string and
+// group storage there for ordinary boxed storage: strings and group slices
+// allocate on the heap when stored in a [Value].
+
+package klog
+
+type (
+	stringHolder string // used in Value.any when the Value is a string
+	groupHolder  []Attr // used in Value.any when the Value is a []Attr
+)
+
+// ValueString returns a [Value] of [KindString]
+func ValueString(v string) Value {
+	return Value{any: stringHolder(v)}
+}
+
+// ValueGroup returns a [Value] of [KindGroup] holding attrs
+func ValueGroup(attrs ...Attr) Value {
+	return Value{any: groupHolder(attrs)}
+}
+
+func (v Value) StringValue() string {
+	s, ok := v.any.(stringHolder)
+	if !ok {
+		return ""
+	}
+	return string(s)
+}
+
+// Group returns the attrs of a [KindGroup] value, or nil otherwise
+func (v Value) Group() []Attr {
+	g, ok := v.any.(groupHolder)
+	if !ok {
+		return nil
+	}
+	return g
+}
+
+func extKind(x any) (Kind, bool) {
+	switch x.(type) {
+	case stringHolder:
+		return KindString, true
+	case groupHolder:
+		return KindGroup, true
+	default:
+		return KindAny, false
+	}
+}
@@ -0,0 +1,301 @@
+package klog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestKindString(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Test string
+		Kind Kind
+	}{
+		{
+			Test: "Any",
+			Kind: KindAny,
+		},
+		{
+			Test: "String",
+			Kind: KindString,
+		},
+		{
+			Test: "Group",
+			Kind: KindGroup,
+		},
+		{
+			Test: "LogValuer",
+			Kind: KindLogValuer,
+		},
+	} {
+		tc := tc
+		t.Run(tc.Test, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+
+			assert.Equal(tc.Test, tc.Kind.String())
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		assert := require.New(t)
+
+		assert.Equal("Kind(99)", Kind(99).String())
+	})
+}
+
+func TestValueGroup(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueGroup(AString("a", "b"), AInt("c", 1))
+	assert.Equal(KindGroup, v.Kind())
+	g := v.Group()
+	assert.Len(g, 2)
+	assert.Equal("a", g[0].Key)
+	assert.Equal("b", g[0].Value.String())
+	assert.Equal("c", g[1].Key)
+	assert.Equal(int64(1), g[1].Value.Int64())
+	assert.Nil(ValueString("not a group").Group())
+}
+
+type testLogValuer struct {
+	v Value
+}
+
+func (t testLogValuer) LogValue() Value {
+	return t.v
+}
+
+func TestValueLogValuer(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueAny(testLogValuer{v: ValueString("resolved")})
+	assert.Equal(KindLogValuer, v.Kind())
+	assert.NotNil(v.LogValuer())
+	resolved := v.Resolve()
+	assert.Equal(KindString, resolved.Kind())
+	assert.Equal("resolved", resolved.StringValue())
+	assert.Nil(ValueString("not a log valuer").LogValuer())
+}
+
+func TestValueString(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueString("hello world")
+	assert.Equal(KindString, v.Kind())
+	assert.Equal("hello world", v.StringValue())
+	assert.Equal("", ValueInt(0).StringValue())
+}
+
+func TestValueTime(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	now := time.Now().In(time.UTC)
+	v := ValueTime(now)
+	assert.Equal(KindTime, v.Kind())
+	assert.True(now.Equal(v.Time()))
+
+	assert.True(ValueTime(time.Time{}).Time().IsZero())
+}
+
+func TestValueBytes(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueBytes([]byte("payload"))
+	assert.Equal(KindBytes, v.Kind())
+	assert.Equal([]byte("payload"), v.Bytes())
+	assert.Nil(ValueString("not bytes").Bytes())
+}
+
+func TestValueEqual(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.True(ValueInt64(1).Equal(ValueInt64(1)))
+	assert.False(ValueInt64(1).Equal(ValueInt64(2)))
+	assert.False(ValueInt64(1).Equal(ValueUint64(1)))
+
+	assert.True(ValueString("a").Equal(ValueString("a")))
+	assert.False(ValueString("a").Equal(ValueString("b")))
+
+	assert.True(ValueBytes([]byte("a")).Equal(ValueBytes([]byte("a"))))
+	assert.False(ValueBytes([]byte("a")).Equal(ValueBytes([]byte("b"))))
+
+	now := time.Now()
+	assert.True(ValueTime(now).Equal(ValueTime(now)))
+	assert.False(ValueTime(now).Equal(ValueTime(now.Add(time.Second))))
+
+	g1 := ValueGroup(AString("a", "b"), AInt("c", 1))
+	g2 := ValueGroup(AString("a", "b"), AInt("c", 1))
+	g3 := ValueGroup(AString("a", "b"), AInt("c", 2))
+	assert.True(g1.Equal(g2))
+	assert.False(g1.Equal(g3))
+
+	assert.True(ValueAny([]int{1, 2}).Equal(ValueAny([]int{1, 2})))
+	assert.False(ValueAny([]int{1, 2}).Equal(ValueAny([]int{1, 3})))
+}
+
+func TestValueAppendFormat(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	assert.Equal("true", string(ValueBool(true).AppendFormat(nil, 'v')))
+	assert.Equal("42", string(ValueInt64(42).AppendFormat(nil, 'v')))
+	assert.Equal("hello", string(ValueString("hello").AppendFormat(nil, 'v')))
+	assert.Equal("payload", string(ValueBytes([]byte("payload")).AppendFormat(nil, 'v')))
+}
+
+type cyclicLogValuer struct {
+	next *cyclicLogValuer
+}
+
+func (v *cyclicLogValuer) LogValue() Value {
+	return ValueAny(v.next)
+}
+
+func TestResolveCycle(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	a := &cyclicLogValuer{}
+	a.next = a
+
+	resolved := ValueAny(a).Resolve()
+	assert.Equal(KindLogValuer, resolved.Kind())
+	var resolveErr *ResolveError
+	assert.True(errors.As(resolved.Any().(error), &resolveErr))
+	assert.ErrorIs(resolveErr, ErrorResolveCycle)
+}
+
+func TestResolveExceedsLimit(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := AGroup("l1", AGroup("l2", AGroup("l3", AInt("leaf", 1))))
+
+	var errs []error
+	err := ValueGroup(v).walk(nil, 1, func(path []string, leaf Value) error {
+		if leaf.Kind() == KindLogValuer {
+			var resolveErr *ResolveError
+			if errors.As(leaf.Any().(error), &resolveErr) {
+				errs = append(errs, resolveErr)
+			}
+		}
+		return nil
+	})
+	assert.NoError(err)
+	assert.NotEmpty(errs)
+	assert.ErrorIs(errs[0], ErrorExceedValueResolveRecursion)
+}
+
+func TestResolveZeroLimitAllowsNonLogValuerLeaf(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	// a plain leaf at the depth boundary is not itself a LogValuer chain, so
+	// it should pass through unchanged rather than being reported as having
+	// exceeded the resolve recursion limit
+	resolved := resolveLogValuer(ValueInt(1), 0)
+	assert.Equal(KindInt64, resolved.Kind())
+	assert.Equal(int64(1), resolved.Int64())
+
+	// a LogValuer still at the boundary, on the other hand, has no budget
+	// left to call LogValue and so is reported as exceeding the limit
+	resolved = resolveLogValuer(ValueAny(&cyclicLogValuer{}), 0)
+	var resolveErr *ResolveError
+	assert.True(errors.As(resolved.Any().(error), &resolveErr))
+	assert.ErrorIs(resolveErr, ErrorExceedValueResolveRecursion)
+}
+
+type slogLogValuer struct {
+	v string
+}
+
+func (v slogLogValuer) LogValue() slog.Value {
+	return slog.StringValue(v.v)
+}
+
+func TestResolveAcrossAttrBoundary(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueGroup(
+		AAny("lazy", slogLogValuer{v: "resolved"}),
+		AGroup("nested", AString("leaf", "value")),
+	)
+
+	resolved := v.Resolve()
+	g := resolved.Group()
+	assert.Equal("resolved", valueFromAttrValue(g[0].Value).StringValue())
+	assert.Equal("value", valueFromAttrValue(g[1].Value).Group()[0].Value.String())
+}
+
+func TestValueWalk(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	v := ValueGroup(
+		AString("a", "1"),
+		AGroup("g", AInt("b", 2)),
+	)
+
+	type visit struct {
+		path string
+		leaf string
+	}
+	var got []visit
+	err := v.Walk(func(path []string, leaf Value) error {
+		key := ""
+		for _, p := range path {
+			key += p + "."
+		}
+		got = append(got, visit{path: key, leaf: string(leaf.AppendFormat(nil, 'v'))})
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]visit{
+		{path: "a.", leaf: "1"},
+		{path: "g.b.", leaf: "2"},
+	}, got)
+}
+
+func BenchmarkValueString(b *testing.B) {
+	b.ReportAllocs()
+	s := "a log line attribute value"
+	for i := 0; i < b.N; i++ {
+		_ = ValueString(s)
+	}
+}
+
+func BenchmarkValueTime(b *testing.B) {
+	b.ReportAllocs()
+	now := time.Now()
+	for i := 0; i < b.N; i++ {
+		_ = ValueTime(now)
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !klog_safe
+
+package klog
+
+import "unsafe"
+
+type (
+	stringptr *byte // used in Value.any when the Value is a string
+	groupptr  *Attr // used in Value.any when the Value is a []Attr
+)
+
+// ValueString returns a [Value] of [KindString]. It does not allocate: the
+// returned Value points directly at v's backing bytes, so v must not be
+// mutated afterward (strings in Go are already immutable, so this is safe
+// for ordinary callers).
+func ValueString(v string) Value {
+	return Value{
+		num: uint64(len(v)),
+		any: stringptr(unsafe.StringData(v)),
+	}
+}
+
+// ValueGroup returns a [Value] of [KindGroup] holding attrs. It does not
+// allocate: the caller must not subsequently mutate attrs.
+func ValueGroup(attrs ...Attr) Value {
+	return Value{
+		num: uint64(len(attrs)),
+		any: groupptr(unsafe.SliceData(attrs)),
+	}
+}
+
+func (v Value) StringValue() string {
+	sp, ok := v.any.(stringptr)
+	if !ok {
+		return ""
+	}
+	return unsafe.String(sp, v.num)
+}
+
+// Group returns the attrs of a [KindGroup] value, or nil otherwise
+func (v Value) Group() []Attr {
+	gp, ok := v.any.(groupptr)
+	if !ok {
+		return nil
+	}
+	return unsafe.Slice((*Attr)(gp), v.num)
+}
+
+func extKind(x any) (Kind, bool) {
+	switch x.(type) {
+	case stringptr:
+		return KindString, true
+	case groupptr:
+		return KindGroup, true
+	default:
+		return KindAny, false
+	}
+}